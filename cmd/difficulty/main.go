@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"guitui/internal/lesson"
+)
+
+// difficulty prints the estimated difficulty of every lesson in the library
+func main() {
+	jsonPath := flag.String("json", "lessons.json", "JSON lesson file")
+	tabDir := flag.String("tabs", "lessons_tab", "directory with .tab lessons")
+	sections := flag.Bool("sections", false, "print the breakdown per section")
+	flag.Parse()
+
+	lessons, err := lesson.LoadLessonsFromMultipleSources(*jsonPath, *tabDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load lessons: %v\n", err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LESSON\tSCORE\tLEVEL\tHEADER\tNOTES/S\tSTRETCH\tJUMP\tSKIPS\tSHIFTS\tTECH\tVARIETY\tFINGER")
+
+	for _, l := range lessons {
+		r := lesson.AnalyzeDifficulty(l)
+		header := l.Difficulty
+		if header == "" {
+			header = "-"
+		}
+		fmt.Fprintf(w, "%s\t%.1f\t%s\t%s\t%s\n", l.Title, r.Score, r.Level, header, formatFactors(r.Factors))
+
+		if *sections {
+			for _, s := range r.Sections {
+				fmt.Fprintf(w, "  %s\t%.1f\t%s\t\t%s\n", s.Name, s.Score, lesson.DifficultyLevel(s.Score), formatFactors(s.Factors))
+			}
+		}
+	}
+	w.Flush()
+}
+
+func formatFactors(f lesson.DifficultyFactors) string {
+	return fmt.Sprintf("%.1f\t%d\t%d\t%d\t%d\t%.2f\t%.2f\t%.2f",
+		f.NotesPerSecond, f.MaxStepStretch, f.MaxMoveStretch, f.StringSkips, f.PositionShifts,
+		f.TechniqueDensity, f.TechniqueVariety, f.FingeringLoad)
+}
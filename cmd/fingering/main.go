@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"guitui/internal/lesson"
+)
+
+// fingering assigns fingers to .tab lessons that lack (fN) annotations.
+// Without -write it only reports how many fingers would be assigned.
+func main() {
+	write := flag.Bool("write", false, "write the assigned fingers back into the files")
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"lessons_tab"}
+	}
+
+	for _, path := range expandTabPaths(paths) {
+		l, err := lesson.LoadTabFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			continue
+		}
+
+		n := lesson.AssignFingers(l)
+		if n == 0 {
+			continue
+		}
+		if *write {
+			if err := lesson.WriteFingers(path, *l); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+				continue
+			}
+			fmt.Printf("%s: wrote %d fingers\n", path, n)
+		} else {
+			fmt.Printf("%s: %d fingers to assign (use -write to save)\n", path, n)
+		}
+	}
+}
+
+func expandTabPaths(paths []string) []string {
+	var files []string
+	for _, p := range paths {
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			// Not a directory: treat as a file
+			files = append(files, p)
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() && (strings.HasSuffix(e.Name(), ".tab") || strings.HasSuffix(e.Name(), ".txt")) {
+				files = append(files, filepath.Join(p, e.Name()))
+			}
+		}
+	}
+	return files
+}
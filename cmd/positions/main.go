@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"guitui/internal/theory"
+)
+
+// positions prints generated scale positions as fretboard diagrams, or with
+// -validate compares them with the hand-written tables in theory.AllScalePositions.
+// A user definitions file is loaded first; -check only reports its errors.
+// Exits with status 1 when validation finds differences or rejected definitions.
+func main() {
+	scaleName := flag.String("scale", "minor_pentatonic", "scale name (theory.Scales key)")
+	rootName := flag.String("root", "A", "root note (A, Bb, F#...)")
+	posType := flag.String("type", "caged", "position system: caged or 3nps")
+	validate := flag.Bool("validate", false, "check the hand-written position tables")
+	defsPath := flag.String("defs", theory.DefinitionsFile, "user definitions file (scales, tunings, positions)")
+	check := flag.Bool("check", false, "only check the definitions file")
+	flag.Parse()
+
+	defErrors := theory.LoadDefinitions(*defsPath)
+	for _, err := range defErrors {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if *check {
+		if len(defErrors) > 0 {
+			fmt.Printf("%d rejected definitions\n", len(defErrors))
+			os.Exit(1)
+		}
+		fmt.Printf("%s: all definitions are valid\n", *defsPath)
+		return
+	}
+
+	if *validate {
+		mismatches := theory.ValidatePositions()
+		for _, m := range mismatches {
+			fmt.Println(m)
+		}
+		if len(mismatches) > 0 {
+			fmt.Printf("%d differences\n", len(mismatches))
+			os.Exit(1)
+		}
+		fmt.Println("all hand-written positions match")
+		return
+	}
+
+	formula, ok := theory.Scales[*scaleName]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "positions: unknown scale %q\n", *scaleName)
+		os.Exit(2)
+	}
+	root, ok := theory.ParseNote(*rootName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "positions: unknown root %q\n", *rootName)
+		os.Exit(2)
+	}
+
+	var positions []theory.Position
+	switch *posType {
+	case "caged":
+		positions = theory.GenerateCAGED(formula, theory.StandardTuning)
+	case "3nps":
+		positions = theory.GenerateThreeNPS(formula, theory.StandardTuning)
+	default:
+		fmt.Fprintf(os.Stderr, "positions: unknown type %q (caged, 3nps)\n", *posType)
+		os.Exit(2)
+	}
+
+	key := theory.ScaleKey(root, *scaleName)
+	rootFret := theory.FindRootFretOn6thString(root)
+	for _, pos := range positions {
+		printPosition(pos, rootFret, key)
+	}
+}
+
+// printPosition draws one position, high e on top, with finger numbers
+func printPosition(pos theory.Position, rootFret int, key theory.Key) {
+	start, end := theory.CalculateFretRange(pos, rootFret)
+	fmt.Printf("Position %d (%s) frets %d-%d, root on strings %v\n", pos.Index, pos.Type, start, end, pos.RootStrings)
+
+	labels := []string{"E", "A", "D", "G", "B", "e"}
+	for s := 5; s >= 0; s-- {
+		var b strings.Builder
+		fingers := map[int]int{}
+		for i, rel := range pos.NotePatterns[s].RelativeFrets {
+			if i < len(pos.FingerPattern[s]) {
+				fingers[start+rel] = pos.FingerPattern[s][i]
+			}
+		}
+		for f := start; f <= end; f++ {
+			if finger, ok := fingers[f]; ok {
+				b.WriteString(fmt.Sprintf("-%d-|", finger))
+			} else {
+				b.WriteString("---|")
+			}
+		}
+
+		var names []string
+		for _, rel := range pos.NotePatterns[s].RelativeFrets {
+			names = append(names, key.Spell(theory.CalculateNote(theory.StandardTuning[s], start+rel)))
+		}
+		fmt.Printf("  %s|%s  %s\n", labels[s], b.String(), strings.Join(names, " "))
+	}
+	fmt.Println()
+}
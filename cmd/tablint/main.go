@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"guitui/internal/lesson"
+)
+
+// tablint checks .tab lessons and prints problems as file:beat: severity: reason.
+// Exits with status 1 when any error is found.
+func main() {
+	showInfo := flag.Bool("info", false, "also print info-level notes")
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"lessons_tab"}
+	}
+
+	files, err := collectTabFiles(paths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tablint: %v\n", err)
+		os.Exit(2)
+	}
+
+	errors := 0
+	for _, path := range files {
+		l, err := lesson.LoadTabFile(path)
+		if err != nil {
+			fmt.Printf("%s: error: %v\n", path, err)
+			errors++
+			continue
+		}
+
+		if l.DetectedKey != nil && *showInfo {
+			fmt.Printf("%s: info: no KEY header, detected %s\n", path, l.DetectedKey)
+		}
+
+		for _, issue := range lesson.CheckFingering(*l) {
+			if issue.Severity == lesson.SeverityInfo && !*showInfo {
+				continue
+			}
+			if issue.Severity == lesson.SeverityError {
+				errors++
+			}
+			fmt.Printf("%s:%s\n", path, issue)
+		}
+	}
+
+	if errors > 0 {
+		os.Exit(1)
+	}
+}
+
+// collectTabFiles expands directories into the .tab/.txt files they contain
+func collectTabFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if !e.IsDir() && (strings.HasSuffix(name, ".tab") || strings.HasSuffix(name, ".txt")) {
+				files = append(files, filepath.Join(p, name))
+			}
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,69 @@
+package lesson
+
+import (
+	"fmt"
+	"strings"
+
+	"guitui/internal/theory"
+)
+
+// ArpeggioBPM is the tempo of generated arpeggio lessons
+const ArpeggioBPM = 80
+
+// ArpeggioLesson turns an arpeggio shape into a lesson: up the shape and
+// back down to the first note, one note per beat. Sweeps are marked for
+// sweep picking; other shapes alternate down and up strokes.
+func ArpeggioLesson(a theory.Arpeggio) Lesson {
+	key := theory.DefaultKey(a.Root, a.Quality.Minor())
+	name := key.Spell(a.Root) + a.Quality.Suffix
+	l := Lesson{
+		Title:     fmt.Sprintf("%s Arpeggio - %s, fret %d", name, shapeTitle(a.Shape), a.Position()),
+		Category:  "arpeggio",
+		BPM:       ArpeggioBPM,
+		KeyStr:    key.String(),
+		ActualKey: a.Root,
+	}
+
+	// Up, then down without repeating the top note
+	order := append([]theory.ArpeggioNote(nil), a.Notes...)
+	for i := len(a.Notes) - 2; i >= 0; i-- {
+		order = append(order, a.Notes[i])
+	}
+
+	for i, n := range order {
+		pick := PickSweep
+		if a.Shape != theory.ArpeggioSweep {
+			pick = PickDown
+			if i%2 == 1 {
+				pick = PickUp
+			}
+		}
+		l.Steps = append(l.Steps, Step{
+			Beat: i + 1,
+			Markers: []Marker{{
+				StringIndex: n.String,
+				Fret:        n.Fret,
+				Finger:      n.Finger,
+				Picking:     pick,
+			}},
+		})
+	}
+	l.RecalculateNotes()
+	return l
+}
+
+// ArpeggioLessons generates a lesson for every position of the chord in
+// one shape, low on the neck first
+func ArpeggioLessons(root theory.Note, quality theory.ChordQuality, shape theory.ArpeggioShape) []Lesson {
+	var lessons []Lesson
+	for _, a := range theory.GenerateArpeggios(root, quality, shape, theory.StandardTuning, NeckFrets) {
+		lessons = append(lessons, ArpeggioLesson(a))
+	}
+	return lessons
+}
+
+// shapeTitle capitalizes a shape name for lesson titles: "String-skip"
+func shapeTitle(shape theory.ArpeggioShape) string {
+	s := string(shape)
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,45 @@
+package lesson
+
+import (
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestArpeggioLesson(t *testing.T) {
+	var am theory.ChordQuality
+	for _, q := range theory.ChordQualities {
+		if q.Suffix == "m" {
+			am = q
+		}
+	}
+	lessons := ArpeggioLessons(theory.A, am, theory.ArpeggioSweep)
+	if len(lessons) == 0 {
+		t.Fatal("no A minor sweeps")
+	}
+	for _, l := range lessons {
+		if l.KeyStr != "Am" || l.ActualKey != theory.A {
+			t.Errorf("%s: key %s", l.Title, l.KeyStr)
+		}
+		// Up the shape and back down: the first note ends it, the top is played once
+		n := len(l.Steps)
+		first, last := l.Steps[0].Markers[0], l.Steps[n-1].Markers[0]
+		if n%2 == 0 || first.StringIndex != last.StringIndex || first.Fret != last.Fret {
+			t.Errorf("%s: %d steps from %+v to %+v", l.Title, n, first, last)
+		}
+		for _, step := range l.Steps {
+			m := step.Markers[0]
+			if m.Picking != PickSweep {
+				t.Errorf("%s beat %d: %s picking", l.Title, step.Beat, m.Picking)
+			}
+			if !theory.IsNoteInScale(m.Note, theory.A, "minor_pentatonic") {
+				t.Errorf("%s beat %d: %s is not in Am", l.Title, step.Beat, theory.NoteNames[m.Note])
+			}
+		}
+	}
+
+	boxes := ArpeggioLessons(theory.A, am, theory.ArpeggioBox)
+	if len(boxes) == 0 || boxes[0].Steps[1].Markers[0].Picking != PickUp {
+		t.Error("box arpeggios don't alternate")
+	}
+}
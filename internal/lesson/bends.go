@@ -0,0 +1,233 @@
+package lesson
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"guitui/internal/theory"
+)
+
+// ParseBendAmount converts a bend amount in steps to semitones:
+// "¼" or "1/4" -> 0.5, "½" -> 1, "1" or "full" -> 2, "1½" or "1 1/2" -> 3
+func ParseBendAmount(s string) (float64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, false
+	}
+	if s == "full" {
+		return 2, true
+	}
+
+	s = strings.NewReplacer("¼", " 1/4", "½", " 1/2", "¾", " 3/4").Replace(s)
+
+	steps := 0.0
+	for _, part := range strings.Fields(s) {
+		if num, den, ok := strings.Cut(part, "/"); ok {
+			n, err1 := strconv.ParseFloat(num, 64)
+			d, err2 := strconv.ParseFloat(den, 64)
+			if err1 != nil || err2 != nil || d == 0 {
+				return 0, false
+			}
+			steps += n / d
+			continue
+		}
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, false
+		}
+		steps += v
+	}
+	return steps * 2, steps > 0
+}
+
+// FormatBendSteps formats semitones as steps the way tabs write them: 1 -> "½", 3 -> "1½"
+func FormatBendSteps(semitones float64) string {
+	whole := int(semitones / 2)
+	var frac string
+	switch rest := semitones - float64(whole)*2; {
+	case rest >= 1.5:
+		frac = "¾"
+	case rest >= 1:
+		frac = "½"
+	case rest >= 0.5:
+		frac = "¼"
+	}
+	if whole == 0 {
+		if frac == "" {
+			return "0"
+		}
+		return frac
+	}
+	return strconv.Itoa(whole) + frac
+}
+
+// parseBend parses bend notation. The curve lists the pitch offsets in
+// semitones above the fretted note that the bend moves through:
+//
+//	7b{1}    -> [0 2]        7b9     -> [0 2]
+//	7b{½}r   -> [0 1 0]      7b9r7   -> [0 2 0]
+//	7b1/4    -> [0 0.5]      7b9r7b9 -> [0 2 0 2]
+//	pb{1}7   -> [2]          pb{1}7r -> [2 0]
+func parseBend(cell string) (TechniqueType, TechniqueParams, bool) {
+	var params TechniqueParams
+	technique := TechBend
+	var curve []float64
+	var rest string
+	fret := 0
+
+	if strings.HasPrefix(cell, "pb{") {
+		end := strings.Index(cell, "}")
+		if end == -1 {
+			return TechNone, params, false
+		}
+		amount, ok := ParseBendAmount(cell[3:end])
+		if !ok {
+			return TechNone, params, false
+		}
+		technique = TechPreBend
+		curve = []float64{amount}
+		rest = cell[end+1:]
+		digits := len(rest) - len(strings.TrimLeft(rest, "0123456789"))
+		fret, _ = strconv.Atoi(rest[:digits])
+		rest = rest[digits:]
+	} else {
+		digits := len(cell) - len(strings.TrimLeft(cell, "0123456789"))
+		if digits == 0 || digits == len(cell) || cell[digits] != 'b' {
+			return TechNone, params, false
+		}
+		fret, _ = strconv.Atoi(cell[:digits])
+		curve = []float64{0}
+		rest = cell[digits:]
+	}
+
+	// Bend (b) and release (r) stages
+	for rest != "" && (rest[0] == 'b' || rest[0] == 'r') {
+		amount, n, ok := parseBendTarget(rest[1:], fret)
+		if !ok {
+			if rest[0] == 'b' {
+				return TechNone, params, false
+			}
+			amount = 0 // Plain "r": back to the fretted pitch
+		}
+		curve = append(curve, amount)
+		rest = rest[1+n:]
+	}
+	if technique == TechBend && len(curve) < 2 {
+		return TechNone, params, false
+	}
+	if strings.HasPrefix(rest, "~") {
+		params.VibratoWidth = "normal"
+		if strings.HasPrefix(rest, "~~") {
+			params.VibratoWidth = "wide"
+		}
+	}
+
+	params.BendCurve = curve
+	params.BendSemitones = maxBend(curve)
+	params.BendSteps = FormatBendSteps(params.BendSemitones)
+	params.BendRelease = len(curve) > 1 && curve[len(curve)-1] < params.BendSemitones
+	return technique, params, true
+}
+
+// parseBendTarget reads what follows b or r: "{1}" (steps), "1/4" (steps) or
+// "9" (target fret). Returns the offset in semitones and the bytes consumed.
+func parseBendTarget(s string, fret int) (float64, int, bool) {
+	if strings.HasPrefix(s, "{") {
+		end := strings.Index(s, "}")
+		if end == -1 {
+			return 0, 0, false
+		}
+		amount, ok := ParseBendAmount(s[1:end])
+		return amount, end + 1, ok
+	}
+
+	digits := len(s) - len(strings.TrimLeft(s, "0123456789"))
+	if digits == 0 {
+		return 0, 0, false
+	}
+	// Fraction of a step: 7b1/4
+	if digits < len(s) && s[digits] == '/' {
+		den := len(s[digits+1:]) - len(strings.TrimLeft(s[digits+1:], "0123456789"))
+		if den > 0 {
+			n := digits + 1 + den
+			amount, ok := ParseBendAmount(s[:n])
+			return amount, n, ok
+		}
+	}
+	target, _ := strconv.Atoi(s[:digits])
+	if target < fret {
+		return 0, 0, false
+	}
+	return float64(target - fret), digits, true
+}
+
+// fillBend derives the curve of bends that only have BendSteps (JSON lessons)
+func (m *Marker) fillBend() {
+	if len(m.TechParams.BendCurve) > 0 {
+		return
+	}
+	amount, ok := ParseBendAmount(m.TechParams.BendSteps)
+	if !ok {
+		return
+	}
+	switch m.Technique {
+	case TechBend:
+		m.TechParams.BendCurve = []float64{0, amount}
+	case TechPreBend:
+		m.TechParams.BendCurve = []float64{amount}
+	default:
+		return
+	}
+	if m.TechParams.BendRelease {
+		m.TechParams.BendCurve = append(m.TechParams.BendCurve, 0)
+	}
+	m.TechParams.BendSemitones = amount
+}
+
+// BendOffsetAt returns how many semitones above the fretted note a bent note
+// sounds beatOffset beats after it is picked. Each stage of the curve takes a
+// beat, or an equal share of the note when it is shorter; a note held with =
+// keeps the last pitch reached.
+func (m Marker) BendOffsetAt(beatOffset float64) float64 {
+	curve := m.TechParams.BendCurve
+	if len(curve) == 0 {
+		return 0
+	}
+	if len(curve) == 1 || beatOffset <= 0 {
+		return curve[0]
+	}
+
+	stages := float64(len(curve) - 1)
+	stageLen := math.Min(1, float64(max(m.Duration, 1))/stages)
+	pos := beatOffset / stageLen
+	if pos >= stages {
+		return curve[len(curve)-1]
+	}
+	i := int(pos)
+	frac := pos - float64(i)
+	return curve[i] + (curve[i+1]-curve[i])*frac
+}
+
+// BendTargetPitch is the highest pitch the bend reaches (quarter tones round down)
+func (m Marker) BendTargetPitch() theory.Pitch {
+	return m.Pitch.Transpose(int(m.TechParams.BendSemitones))
+}
+
+// BendTargetFret is the fret that sounds the bend's target pitch on the same
+// string, or -1 when the bend is less than a semitone
+func (m Marker) BendTargetFret() int {
+	semitones := int(m.TechParams.BendSemitones)
+	if semitones == 0 || (m.Technique != TechBend && m.Technique != TechPreBend) {
+		return -1
+	}
+	return m.Fret + semitones
+}
+
+func maxBend(curve []float64) float64 {
+	highest := 0.0
+	for _, v := range curve {
+		highest = math.Max(highest, v)
+	}
+	return highest
+}
@@ -0,0 +1,119 @@
+package lesson
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseBendAmount(t *testing.T) {
+	tests := []struct {
+		steps     string
+		semitones float64
+		ok        bool
+	}{
+		{"¼", 0.5, true},
+		{"1/4", 0.5, true},
+		{"½", 1, true},
+		{"1", 2, true},
+		{"full", 2, true},
+		{"1½", 3, true},
+		{"1 1/2", 3, true},
+		{"2", 4, true},
+		{"", 0, false},
+		{"0", 0, false},
+		{"1/0", 0, false},
+		{"lots", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseBendAmount(tt.steps)
+		if got != tt.semitones || ok != tt.ok {
+			t.Errorf("ParseBendAmount(%q) = %g, %v, want %g, %v", tt.steps, got, ok, tt.semitones, tt.ok)
+		}
+	}
+}
+
+func TestFormatBendSteps(t *testing.T) {
+	tests := []struct {
+		semitones float64
+		want      string
+	}{
+		{0, "0"}, {0.5, "¼"}, {1, "½"}, {2, "1"}, {3, "1½"}, {3.5, "1¾"}, {4, "2"},
+	}
+	for _, tt := range tests {
+		if got := FormatBendSteps(tt.semitones); got != tt.want {
+			t.Errorf("FormatBendSteps(%g) = %q, want %q", tt.semitones, got, tt.want)
+		}
+	}
+}
+
+func TestParseBend(t *testing.T) {
+	tests := []struct {
+		cell      string
+		technique TechniqueType
+		curve     []float64
+		release   bool
+		vibrato   string
+	}{
+		{"7b9", TechBend, []float64{0, 2}, false, ""},
+		{"7b{1}", TechBend, []float64{0, 2}, false, ""},
+		{"7b{½}r", TechBend, []float64{0, 1, 0}, true, ""},
+		{"7b1/4", TechBend, []float64{0, 0.5}, false, ""},
+		{"7b9r7", TechBend, []float64{0, 2, 0}, true, ""},
+		{"7b9r7b9", TechBend, []float64{0, 2, 0, 2}, false, ""},
+		{"7b10~~", TechBend, []float64{0, 3}, false, "wide"},
+		{"pb{1}7", TechPreBend, []float64{2}, false, ""},
+		{"pb{1}7r", TechPreBend, []float64{2, 0}, true, ""},
+	}
+	for _, tt := range tests {
+		technique, params, ok := parseBend(tt.cell)
+		if !ok || technique != tt.technique || !slices.Equal(params.BendCurve, tt.curve) ||
+			params.BendRelease != tt.release || params.VibratoWidth != tt.vibrato {
+			t.Errorf("parseBend(%q) = %s %v release %v vibrato %q (ok %v), want %s %v release %v vibrato %q",
+				tt.cell, technique, params.BendCurve, params.BendRelease, params.VibratoWidth, ok,
+				tt.technique, tt.curve, tt.release, tt.vibrato)
+		}
+	}
+	for _, cell := range []string{"7", "7b", "9b7", "pb{1", "7h9"} {
+		if _, _, ok := parseBend(cell); ok {
+			t.Errorf("parseBend(%q) accepted", cell)
+		}
+	}
+}
+
+func TestBendOffsetAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		curve    []float64
+		duration int
+		at       float64
+		want     float64
+	}{
+		{"picked", []float64{0, 2}, 1, 0, 0},
+		{"halfway up", []float64{0, 2}, 1, 0.5, 1},
+		{"held at the top", []float64{0, 2}, 3, 2.5, 2},
+		{"bend and release in one beat", []float64{0, 2, 0}, 1, 0.5, 2},
+		{"release of a two-beat note", []float64{0, 2, 0}, 2, 1.5, 1},
+		{"pre-bend", []float64{2}, 1, 0.5, 2},
+	}
+	for _, tt := range tests {
+		m := Marker{Duration: tt.duration, Technique: TechBend, TechParams: TechniqueParams{BendCurve: tt.curve}}
+		if got := m.BendOffsetAt(tt.at); got != tt.want {
+			t.Errorf("%s: offset %g at beat +%g, want %g", tt.name, got, tt.at, tt.want)
+		}
+	}
+}
+
+func TestBendTarget(t *testing.T) {
+	l := Lesson{Steps: []Step{{Beat: 1, Markers: []Marker{{
+		StringIndex: 3, Fret: 7, Technique: TechBend,
+		TechParams: TechniqueParams{BendSteps: "1", BendRelease: true}, // JSON lessons: steps only
+	}}}}}
+	l.RecalculateNotes()
+	m := l.Steps[0].Markers[0]
+	if !slices.Equal(m.TechParams.BendCurve, []float64{0, 2, 0}) {
+		t.Errorf("curve %v, want [0 2 0]", m.TechParams.BendCurve)
+	}
+	if m.BendTargetFret() != 9 || m.BendTargetPitch() != m.Pitch+2 {
+		t.Errorf("target fret %d pitch %s, want fret 9 pitch %s", m.BendTargetFret(), m.BendTargetPitch(), m.Pitch+2)
+	}
+}
@@ -0,0 +1,115 @@
+package lesson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Capo describes a capo clamped on the neck. Fret numbers in the tab are
+// relative to the capo on the strings it covers (tab 0 = capo fret).
+type Capo struct {
+	Fret    int   `json:"fret"`
+	Strings []int `json:"strings,omitempty"` // covered string indexes, empty = all (partial capo otherwise)
+}
+
+// Active reports whether a capo is on the neck
+func (c Capo) Active() bool {
+	return c.Fret > 0
+}
+
+// Covers reports whether the capo presses the string
+func (c Capo) Covers(stringIdx int) bool {
+	if !c.Active() {
+		return false
+	}
+	if len(c.Strings) == 0 {
+		return true
+	}
+	for _, s := range c.Strings {
+		if s == stringIdx {
+			return true
+		}
+	}
+	return false
+}
+
+// AbsoluteFret converts a tab (capo-relative) fret to the real fret on the neck
+func (c Capo) AbsoluteFret(stringIdx, fret int) int {
+	if fret < 0 || !c.Covers(stringIdx) {
+		return fret
+	}
+	return fret + c.Fret
+}
+
+// AbsoluteMarker returns the marker with its fret and technique target moved to real frets
+func (c Capo) AbsoluteMarker(m Marker) Marker {
+	if !c.Covers(m.StringIndex) || m.Fret < 0 {
+		return m
+	}
+	m.Fret = c.AbsoluteFret(m.StringIndex, m.Fret)
+	if m.TechParams.TargetFret > 0 {
+		m.TechParams.TargetFret += c.Fret
+	}
+	return m
+}
+
+// RelativeFret converts a real fret to the capo-relative tab fret
+func (c Capo) RelativeFret(stringIdx, fret int) int {
+	if fret < 0 || !c.Covers(stringIdx) {
+		return fret
+	}
+	return fret - c.Fret
+}
+
+// String formats the capo the way the CAPO: header writes it
+func (c Capo) String() string {
+	if !c.Active() {
+		return ""
+	}
+	if len(c.Strings) == 0 {
+		return strconv.Itoa(c.Fret)
+	}
+	var names strings.Builder
+	for s := 0; s < 6; s++ {
+		if c.Covers(s) {
+			names.WriteString(stringLabel(s))
+		}
+	}
+	return fmt.Sprintf("%d %s", c.Fret, names.String())
+}
+
+// ParseCapo parses a CAPO: header. "2" is a full capo at fret 2,
+// "2 ADGB" or "2 (ADGB)" a partial capo on the listed strings (tab string names).
+func ParseCapo(s string) (Capo, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return Capo{}, nil
+	}
+
+	fret, err := strconv.Atoi(fields[0])
+	if err != nil || fret < 0 || fret > NeckFrets {
+		return Capo{}, fmt.Errorf("invalid capo fret %q", fields[0])
+	}
+	capo := Capo{Fret: fret}
+
+	names := strings.Trim(strings.Join(fields[1:], ""), "()")
+	for _, r := range names {
+		idx, ok := stringNames[string(r)]
+		if !ok {
+			return Capo{}, fmt.Errorf("unknown string %q in capo %q", r, s)
+		}
+		capo.Strings = append(capo.Strings, idx)
+	}
+	return capo, nil
+}
+
+// stringLabel returns the tab name of a string index (0 = "E", 5 = "e")
+func stringLabel(stringIdx int) string {
+	for name, idx := range stringNames {
+		if idx == stringIdx {
+			return name
+		}
+	}
+	return "?"
+}
@@ -0,0 +1,75 @@
+package lesson
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseCapo(t *testing.T) {
+	tests := []struct {
+		header  string
+		fret    int
+		strings []int
+		err     bool
+	}{
+		{"", 0, nil, false},
+		{"2", 2, nil, false},
+		{"2 ADGB", 2, []int{1, 2, 3, 4}, false},
+		{"7 (ADG)", 7, []int{1, 2, 3}, false},
+		{"4 A D G", 4, []int{1, 2, 3}, false},
+		{"x", 0, nil, true},
+		{"25", 0, nil, true},
+		{"2 ADX", 0, nil, true},
+	}
+	for _, tt := range tests {
+		c, err := ParseCapo(tt.header)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseCapo(%q) error %v, want error %v", tt.header, err, tt.err)
+			continue
+		}
+		if c.Fret != tt.fret || !slices.Equal(c.Strings, tt.strings) {
+			t.Errorf("ParseCapo(%q) = %+v, want fret %d strings %v", tt.header, c, tt.fret, tt.strings)
+		}
+	}
+}
+
+func TestCapoString(t *testing.T) {
+	for _, header := range []string{"", "2", "2 ADGB", "5 Ee"} {
+		c, err := ParseCapo(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.String(); got != header {
+			t.Errorf("capo %q formats as %q", header, got)
+		}
+	}
+}
+
+func TestCapoFrets(t *testing.T) {
+	partial := Capo{Fret: 2, Strings: []int{1, 2, 3, 4}} // Open E strings ring below the capo
+	tests := []struct {
+		name     string
+		capo     Capo
+		s, fret  int
+		absolute int
+	}{
+		{"no capo", Capo{}, 0, 3, 3},
+		{"full capo", Capo{Fret: 3}, 0, 0, 3},
+		{"muted", Capo{Fret: 3}, 0, -1, -1},
+		{"covered string", partial, 2, 2, 4},
+		{"open low E", partial, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.capo.AbsoluteFret(tt.s, tt.fret); got != tt.absolute {
+			t.Errorf("%s: absolute fret %d, want %d", tt.name, got, tt.absolute)
+		}
+		if got := tt.capo.RelativeFret(tt.s, tt.absolute); got != tt.fret {
+			t.Errorf("%s: relative fret %d, want %d", tt.name, got, tt.fret)
+		}
+	}
+
+	m := Marker{StringIndex: 2, Fret: 2, Technique: TechSlide, TechParams: TechniqueParams{TargetFret: 4}}
+	if got := partial.AbsoluteMarker(m); got.Fret != 4 || got.TechParams.TargetFret != 6 {
+		t.Errorf("slide 2/4 under the capo at 2 = %d/%d, want 4/6", got.Fret, got.TechParams.TargetFret)
+	}
+}
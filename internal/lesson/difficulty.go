@@ -0,0 +1,260 @@
+package lesson
+
+import "math"
+
+const (
+	// defaultAnalysisBPM is used when a lesson has no BPM header
+	defaultAnalysisBPM = 120
+
+	// handSpan is how many frets the hand covers without shifting (one finger per fret)
+	handSpan = 4
+
+	// fullVariety is how many different techniques make TechniqueVariety 1
+	fullVariety = 6
+)
+
+// Difficulty levels, same words as the DIFFICULTY: header
+const (
+	LevelBeginner     = "beginner"
+	LevelIntermediate = "intermediate"
+	LevelAdvanced     = "advanced"
+	LevelExpert       = "expert"
+)
+
+// How hard each technique is relative to a plain picked note (0-1)
+var techniqueWeights = map[TechniqueType]float64{
+	TechBend:     0.8,
+	TechPreBend:  0.9,
+	TechSlide:    0.4,
+	TechHammer:   0.3,
+	TechPullOff:  0.35,
+	TechVibrato:  0.5,
+	TechTap:      1.0,
+	TechHarmonic: 0.6,
+	TechPinch:    0.9,
+	TechTrill:    0.8,
+}
+
+// DifficultyFactors holds the raw measurements a difficulty score is built from
+type DifficultyFactors struct {
+	NotesPerSecond   float64 // played notes per second at the lesson BPM
+	MaxStepStretch   int     // widest fret stretch inside one step (chords, double stops)
+	MaxMoveStretch   int     // widest fret jump between adjacent steps
+	StringSkips      int     // moves between single notes that skip at least one string
+	PositionShifts   int     // times the hand leaves its 4-fret position
+	TechniqueDensity float64 // weighted techniques per note (0-1)
+	TechniqueVariety float64 // different techniques used, fullVariety or more = 1
+	FingeringLoad    float64 // pinky use and same-finger jumps per note (0-1)
+	Notes            int
+}
+
+// SectionDifficulty is the score of one SECTION block of a tab lesson, or of
+// the whole lesson when it has no sections
+type SectionDifficulty struct {
+	Name    string
+	Beats   int
+	Score   float64
+	Factors DifficultyFactors
+}
+
+// DifficultyReport is the result of AnalyzeDifficulty
+type DifficultyReport struct {
+	Score    float64 // 0 (trivial) - 10 (very hard)
+	Level    string
+	Factors  DifficultyFactors
+	Sections []SectionDifficulty
+}
+
+// AnalyzeDifficulty scores a lesson from its steps: speed, stretches, string
+// skips, position shifts, technique density and fingering. Every SECTION
+// block of a tab lesson is scored on its own; the lesson is measured over
+// all of them, not only the first section that is played.
+func AnalyzeDifficulty(l Lesson) DifficultyReport {
+	bpm := l.BPM
+	if bpm <= 0 {
+		bpm = defaultAnalysisBPM
+	}
+
+	sections := l.Sections
+	if len(sections) == 0 {
+		sections = []TabSection{{Name: "Whole lesson", Steps: l.Steps}}
+	}
+
+	var report DifficultyReport
+	var steps []Step
+	totalBeats := 0
+	for _, section := range sections {
+		beats := BuildIndex(Lesson{Steps: section.Steps}).Beats
+		sf := measureDifficulty(section.Steps, beats, bpm)
+		report.Sections = append(report.Sections, SectionDifficulty{
+			Name:    section.Name,
+			Beats:   beats,
+			Score:   scoreDifficulty(sf),
+			Factors: sf,
+		})
+		steps = append(steps, section.Steps...)
+		totalBeats += beats
+	}
+
+	report.Factors = measureDifficulty(steps, totalBeats, bpm)
+	report.Score = scoreDifficulty(report.Factors)
+	report.Level = DifficultyLevel(report.Score)
+	return report
+}
+
+// DifficultyLevel maps a 0-10 score to a level name
+func DifficultyLevel(score float64) string {
+	switch {
+	case score < 2.5:
+		return LevelBeginner
+	case score < 5:
+		return LevelIntermediate
+	case score < 7.5:
+		return LevelAdvanced
+	default:
+		return LevelExpert
+	}
+}
+
+// measureDifficulty collects the raw factors over a run of steps lasting `beats` beats
+func measureDifficulty(steps []Step, beats, bpm int) DifficultyFactors {
+	var f DifficultyFactors
+	var techLoad, fingerLoad float64
+	techniques := map[TechniqueType]bool{}
+
+	handPos := -1               // fret under the index finger, -1 = not placed yet
+	prevFrets := []int{}        // fretted notes of the previous step
+	prevString := -1            // string of the previous single-note step
+	prevFinger := map[int]int{} // finger -> fret in the previous step
+
+	for _, step := range steps {
+		var frets []int
+		fingers := map[int]int{}
+
+		for _, m := range step.Markers {
+			if m.Fret < 0 {
+				continue
+			}
+			f.Notes++
+			techLoad += techniqueWeights[m.Technique]
+			if m.Technique != TechNone {
+				techniques[m.Technique] = true
+			}
+
+			if m.Fret > 0 {
+				frets = append(frets, m.Fret)
+			}
+			if m.Finger == 4 {
+				fingerLoad += 0.5
+			}
+			if m.Finger > 0 {
+				// Same finger landing on another fret right away (not a slide) is a jump
+				if pf, ok := prevFinger[m.Finger]; ok && pf != m.Fret {
+					fingerLoad += 0.5
+				}
+				fingers[m.Finger] = m.Fret
+				if m.Technique == TechSlide && m.TechParams.TargetFret > 0 {
+					fingers[m.Finger] = m.TechParams.TargetFret
+				}
+			}
+		}
+
+		if len(frets) > 0 {
+			lo, hi := minMax(frets)
+			if stretch := hi - lo + 1; stretch > f.MaxStepStretch {
+				f.MaxStepStretch = stretch
+			}
+
+			if len(prevFrets) > 0 {
+				plo, phi := minMax(prevFrets)
+				move := max(absInt(hi-plo), absInt(phi-lo))
+				if move > f.MaxMoveStretch {
+					f.MaxMoveStretch = move
+				}
+			}
+
+			switch {
+			case handPos < 0:
+				handPos = lo
+			case lo < handPos:
+				handPos = lo
+				f.PositionShifts++
+			case hi > handPos+handSpan-1:
+				handPos = hi - handSpan + 1
+				f.PositionShifts++
+			}
+			prevFrets = frets
+		}
+
+		if len(step.Markers) == 1 && step.Markers[0].Fret >= 0 {
+			s := step.Markers[0].StringIndex
+			if prevString >= 0 && absInt(s-prevString) >= 2 {
+				f.StringSkips++
+			}
+			prevString = s
+		} else if len(step.Markers) > 1 {
+			prevString = -1
+		}
+
+		if len(step.Markers) > 0 {
+			prevFinger = fingers
+		}
+	}
+
+	if beats > 0 {
+		seconds := float64(beats) * 60 / float64(bpm)
+		f.NotesPerSecond = float64(f.Notes) / seconds
+	}
+	if f.Notes > 0 {
+		f.TechniqueDensity = math.Min(1, techLoad/float64(f.Notes))
+		f.FingeringLoad = math.Min(1, fingerLoad/float64(f.Notes))
+	}
+	f.TechniqueVariety = math.Min(1, float64(len(techniques))/fullVariety)
+
+	return f
+}
+
+// scoreDifficulty combines the factors into a 0-10 score. Weights add up to
+// 10 and are fitted to the DIFFICULTY: headers of lessons_tab: techniques,
+// how many and how varied, set the level more than speed or stretches do.
+func scoreDifficulty(f DifficultyFactors) float64 {
+	if f.Notes == 0 {
+		return 0
+	}
+
+	notes := float64(f.Notes)
+	score := 2.0*clamp01(f.NotesPerSecond/8) + // 8 notes/s is shred territory
+		0.5*clamp01(float64(f.MaxStepStretch-handSpan+1)/4) +
+		0.5*clamp01(float64(f.MaxMoveStretch-handSpan)/8) +
+		0.5*clamp01(2*float64(f.StringSkips)/notes) +
+		0.5*clamp01(4*float64(f.PositionShifts)/notes) +
+		3.0*f.TechniqueDensity +
+		2.5*f.TechniqueVariety +
+		0.5*f.FingeringLoad
+
+	return math.Round(score*10) / 10
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+func minMax(values []int) (lo, hi int) {
+	lo, hi = values[0], values[0]
+	for _, v := range values[1:] {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+package lesson
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzeDifficultyMatchesHeaders(t *testing.T) {
+	tests := []struct {
+		file     string
+		sections int
+	}{
+		{"01_a_minor_pentatonic_box1.tab", 1},
+		{"07_technique_test.tab", 1},
+		{"test_all_techniques.tab", 9},
+		{"test_bend_new.tab", 4},
+		{"test_hold_duration.tab", 3},
+		{"test_picking.tab", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			l, err := LoadTabFile(filepath.Join("..", "..", "lessons_tab", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if l.Difficulty == "" {
+				t.Fatal("no DIFFICULTY header")
+			}
+			r := AnalyzeDifficulty(*l)
+			if r.Level != l.Difficulty {
+				t.Errorf("scored %.1f (%s), header says %s", r.Score, r.Level, l.Difficulty)
+			}
+			if len(r.Sections) != tt.sections {
+				t.Errorf("%d sections, want %d", len(r.Sections), tt.sections)
+			}
+		})
+	}
+}
+
+func TestParseTabSections(t *testing.T) {
+	lines := []string{
+		"TITLE: Two sections",
+		"SECTION 1: FIRST",
+		"e|5|7|",
+		"B|-|-|",
+		"SECTION 2: SECOND",
+		"e|-|-|-|",
+		"B|5|6|8|",
+		"NOTES:",
+		"SECTION 3: NOT A BLOCK",
+		"e|1|",
+	}
+	sections := parseTabSections(lines, Capo{})
+	if len(sections) != 2 {
+		t.Fatalf("%d sections, want 2", len(sections))
+	}
+	for i, want := range []struct {
+		name  string
+		steps int
+		beat1 int // String of the note on beat 1
+	}{
+		{"SECTION 1: FIRST", 2, 5},
+		{"SECTION 2: SECOND", 3, 4},
+	} {
+		s := sections[i]
+		if s.Name != want.name || len(s.Steps) != want.steps {
+			t.Errorf("section %d = %q with %d steps, want %q with %d", i, s.Name, len(s.Steps), want.name, want.steps)
+			continue
+		}
+		if m := s.Steps[0].Markers; len(m) != 1 || m[0].StringIndex != want.beat1 || s.Steps[0].Beat != 1 {
+			t.Errorf("section %d beat 1 = %+v", i, s.Steps[0])
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package lesson
+
+import (
+	"fmt"
+	"sort"
+)
+
+// IssueSeverity tells how bad a fingering problem is
+type IssueSeverity string
+
+const (
+	SeverityError   IssueSeverity = "error"   // physically impossible
+	SeverityWarning IssueSeverity = "warning" // playable but inefficient or awkward
+	SeverityInfo    IssueSeverity = "info"
+)
+
+// FingeringIssue is one problem found by CheckFingering
+type FingeringIssue struct {
+	Beat        int
+	StringIndex int
+	Fret        int
+	Finger      int
+	Severity    IssueSeverity
+	Reason      string
+}
+
+func (i FingeringIssue) String() string {
+	if i.Fret < 0 {
+		return fmt.Sprintf("beat %d: %s: %s", i.Beat, i.Severity, i.Reason)
+	}
+	return fmt.Sprintf("beat %d, string %d fret %d: %s: %s",
+		i.Beat, 6-i.StringIndex, i.Fret, i.Severity, i.Reason)
+}
+
+// maxFingerStretch[a][b] is the widest fret distance between finger a and finger b (a < b)
+// a normal hand can hold at the same time. 1-to-4 may cover 5 frets (6 fret positions).
+var maxFingerStretch = [5][5]int{
+	1: {2: 3, 3: 4, 4: 5},
+	2: {3: 2, 4: 3},
+	3: {4: 2},
+}
+
+// heldMarker is a note still sounding at some beat
+type heldMarker struct {
+	marker Marker
+	beat   int // beat where the note started
+}
+
+// CheckFingering walks the steps and flags physically implausible or
+// inefficient fingerings. Notes still held from earlier steps count as
+// occupying their finger.
+func CheckFingering(l Lesson) []FingeringIssue {
+	var issues []FingeringIssue
+
+	if !hasFingerAnnotations(l) {
+		if BuildIndex(l).NoteCount > 0 {
+			issues = append(issues, FingeringIssue{
+				Beat: 1, Fret: -1, Severity: SeverityInfo,
+				Reason: "no finger annotations (fN) in this lesson",
+			})
+		}
+		return issues
+	}
+
+	var held []heldMarker
+	prevFingers := map[int]Marker{} // finger -> marker in the previous step
+
+	for _, step := range l.Steps {
+		// Drop notes that have stopped sounding, then add this step's notes
+		var sounding []heldMarker
+		for _, h := range held {
+			if h.beat+h.marker.Duration-1 >= step.Beat {
+				sounding = append(sounding, h)
+			}
+		}
+		for _, m := range step.Markers {
+			sounding = append(sounding, heldMarker{marker: m, beat: step.Beat})
+		}
+		held = sounding
+
+		for _, m := range step.Markers {
+			issues = append(issues, checkMarker(step.Beat, m)...)
+		}
+		issues = append(issues, checkSimultaneous(step.Beat, held)...)
+		issues = append(issues, checkFingerJumps(step.Beat, step.Markers, prevFingers)...)
+
+		if len(step.Markers) > 0 {
+			prevFingers = map[int]Marker{}
+			for _, m := range step.Markers {
+				if usesFretHand(m) && m.Finger > 0 {
+					prevFingers[m.Finger] = m
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Beat < issues[j].Beat })
+	return issues
+}
+
+// usesFretHand reports whether the fretting hand holds this note down
+func usesFretHand(m Marker) bool {
+	if m.Technique == TechHarmonic {
+		return m.Fret > 0 && m.TechParams.HarmonicType == HarmonicArtificial
+	}
+	return m.Fret > 0 && m.Technique != TechTap
+}
+
+func hasFingerAnnotations(l Lesson) bool {
+	for _, step := range l.Steps {
+		for _, m := range step.Markers {
+			if m.Finger > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkMarker flags problems of a single note
+func checkMarker(beat int, m Marker) []FingeringIssue {
+	issue := FingeringIssue{Beat: beat, StringIndex: m.StringIndex, Fret: m.Fret, Finger: m.Finger}
+
+	switch {
+	case m.Finger < 0 || m.Finger > 4:
+		issue.Severity = SeverityError
+		issue.Reason = fmt.Sprintf("finger %d does not exist (use 1-4)", m.Finger)
+	case m.Fret == 0 && m.Finger > 0:
+		issue.Severity = SeverityWarning
+		issue.Reason = fmt.Sprintf("finger %d on an open string", m.Finger)
+	case usesFretHand(m) && m.Finger == 0:
+		issue.Severity = SeverityError
+		issue.Reason = "fretted note marked as finger 0 (open)"
+	default:
+		return nil
+	}
+	return []FingeringIssue{issue}
+}
+
+// checkSimultaneous flags impossible combinations of notes held at the same time
+func checkSimultaneous(beat int, held []heldMarker) []FingeringIssue {
+	var issues []FingeringIssue
+
+	for i := 0; i < len(held); i++ {
+		a := held[i].marker
+		if !usesFretHand(a) || a.Finger < 1 || a.Finger > 4 {
+			continue
+		}
+		for j := i + 1; j < len(held); j++ {
+			b := held[j].marker
+			if !usesFretHand(b) || b.Finger < 1 || b.Finger > 4 {
+				continue
+			}
+			// Only report pairs involving a note that starts on this beat
+			if held[i].beat != beat && held[j].beat != beat {
+				continue
+			}
+			issue := FingeringIssue{Beat: beat, StringIndex: b.StringIndex, Fret: b.Fret, Finger: b.Finger}
+
+			if a.Finger == b.Finger {
+				// Same fret on several strings is a barre, which is fine
+				if a.Fret != b.Fret {
+					issue.Severity = SeverityError
+					issue.Reason = fmt.Sprintf("finger %d also holds fret %d on string %d", a.Finger, a.Fret, 6-a.StringIndex)
+					issues = append(issues, issue)
+				}
+				continue
+			}
+
+			lo, hi := a, b
+			if lo.Finger > hi.Finger {
+				lo, hi = hi, lo
+			}
+			if lo.Fret > hi.Fret {
+				issue.Severity = SeverityError
+				issue.Reason = fmt.Sprintf("fingers crossed: finger %d on fret %d (string %d) is above finger %d on fret %d (string %d)",
+					lo.Finger, lo.Fret, 6-lo.StringIndex, hi.Finger, hi.Fret, 6-hi.StringIndex)
+				issues = append(issues, issue)
+				continue
+			}
+			if span, limit := hi.Fret-lo.Fret, maxFingerStretch[lo.Finger][hi.Finger]; span > limit {
+				issue.Severity = SeverityError
+				issue.Reason = fmt.Sprintf("finger %d-%d stretch of %d frets to string %d (max %d)",
+					lo.Finger, hi.Finger, span, 6-b.StringIndex, limit)
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkFingerJumps flags a finger that jumps to another fret on a different
+// string in the very next step, which usually means a finger swap was missed
+func checkFingerJumps(beat int, markers []Marker, prev map[int]Marker) []FingeringIssue {
+	var issues []FingeringIssue
+
+	for _, m := range markers {
+		if !usesFretHand(m) || m.Finger == 0 {
+			continue
+		}
+		p, ok := prev[m.Finger]
+		if !ok || p.StringIndex == m.StringIndex || p.Fret == m.Fret {
+			continue
+		}
+		// A slide carries the finger to its target fret on purpose
+		if p.Technique == TechSlide && p.TechParams.TargetFret == m.Fret {
+			continue
+		}
+		issues = append(issues, FingeringIssue{
+			Beat: beat, StringIndex: m.StringIndex, Fret: m.Fret, Finger: m.Finger,
+			Severity: SeverityWarning,
+			Reason: fmt.Sprintf("finger %d jumps from fret %d to fret %d across strings",
+				m.Finger, p.Fret, m.Fret),
+		})
+	}
+
+	return issues
+}
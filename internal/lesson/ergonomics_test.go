@@ -0,0 +1,70 @@
+package lesson
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckFingering(t *testing.T) {
+	// n is a fretted note with its finger
+	n := func(s, fret, finger int) Marker {
+		return Marker{StringIndex: s, Fret: fret, Finger: finger, Duration: 1}
+	}
+	tests := []struct {
+		name     string
+		steps    []Step
+		severity IssueSeverity // Of the only issue, "" = none
+		reason   string
+	}{
+		{"box shape", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 5, 1)}},
+			{Beat: 2, Markers: []Marker{n(0, 8, 4)}},
+		}, "", ""},
+		{"no fingers", []Step{
+			{Beat: 1, Markers: []Marker{{StringIndex: 0, Fret: 5}}},
+		}, SeverityInfo, "no finger annotations"},
+		{"finger 5", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 5, 5)}},
+		}, SeverityError, "finger 5 does not exist"},
+		{"finger on open string", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 0, 1), n(1, 2, 2)}},
+		}, SeverityWarning, "finger 1 on an open string"},
+		{"barre", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 5, 1), n(1, 5, 1), n(2, 7, 3)}},
+		}, "", ""},
+		{"one finger, two frets", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 5, 1), n(1, 7, 1)}},
+		}, SeverityError, "finger 1 also holds fret 5"},
+		{"crossed fingers", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 7, 1), n(1, 5, 3)}},
+		}, SeverityError, "fingers crossed"},
+		{"1-4 stretch", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 2, 1), n(1, 8, 4)}},
+		}, SeverityError, "finger 1-4 stretch of 6 frets"},
+		{"held note stretch", []Step{
+			{Beat: 1, Markers: []Marker{{StringIndex: 0, Fret: 3, Finger: 1, Duration: 2}}},
+			{Beat: 2, Markers: []Marker{n(1, 7, 2)}},
+		}, SeverityError, "finger 1-2 stretch of 4 frets to string 5"},
+		{"finger jump", []Step{
+			{Beat: 1, Markers: []Marker{n(0, 5, 1)}},
+			{Beat: 2, Markers: []Marker{n(1, 7, 1)}},
+		}, SeverityWarning, "finger 1 jumps from fret 5 to fret 7"},
+		{"slide carries the finger", []Step{
+			{Beat: 1, Markers: []Marker{{StringIndex: 0, Fret: 5, Finger: 1, Duration: 1,
+				Technique: TechSlide, TechParams: TechniqueParams{TargetFret: 7}}}},
+			{Beat: 2, Markers: []Marker{n(1, 7, 1)}},
+		}, "", ""},
+	}
+	for _, tt := range tests {
+		issues := CheckFingering(Lesson{Steps: tt.steps})
+		if tt.severity == "" {
+			if len(issues) > 0 {
+				t.Errorf("%s: %v", tt.name, issues)
+			}
+			continue
+		}
+		if len(issues) != 1 || issues[0].Severity != tt.severity || !strings.Contains(issues[0].Reason, tt.reason) {
+			t.Errorf("%s: %v, want one %s %q", tt.name, issues, tt.severity, tt.reason)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package lesson
+
+import (
+	"strings"
+
+	"guitui/internal/theory"
+)
+
+// ExerciseDirection is the order an exercise walks its strings in
+type ExerciseDirection string
+
+const (
+	DirectionUp     ExerciseDirection = "up"      // Low string to high
+	DirectionDown   ExerciseDirection = "down"    // High string to low, fingers reversed
+	DirectionUpDown ExerciseDirection = "up-down" // Up, then back down
+)
+
+// ExerciseBPM is the tempo of generated exercises
+const ExerciseBPM = 60
+
+// Finger orders for exercises, one fret per finger
+var (
+	PermutationsChromatic = [][]int{{1, 2, 3, 4}}
+	Permutations1324      = [][]int{{1, 3, 2, 4}}
+	PermutationsSpider    = fingerPermutations([]int{1, 2, 3, 4})
+)
+
+// String orders for exercises (0 = low E); DirectionDown plays them backwards
+var (
+	StringsAdjacent = []int{0, 1, 2, 3, 4, 5}
+	StringsSkipping = []int{0, 2, 1, 3, 2, 4, 3, 5}
+)
+
+// ExerciseConfig describes a one-finger-per-fret exercise. Each string in
+// Strings plays the next finger order of Permutations on frets StartFret to
+// StartFret+3; the whole pass repeats Repeats times, moving Shift frets
+// each time.
+type ExerciseConfig struct {
+	Name         string
+	Permutations [][]int // Finger orders, used in turn string by string
+	StartFret    int     // Fret under the index finger
+	Strings      []int   // String order of one pass (0 = low E)
+	Direction    ExerciseDirection
+	Picking      string // Stroke pattern repeated over the notes: "d u", "d"
+	Shift        int    // Frets to move after each pass, e.g. 1 to climb, -1 to descend
+	Repeats      int    // Number of passes
+}
+
+// ExercisePresets are the exercises listed with the lessons
+var ExercisePresets = []ExerciseConfig{
+	{Name: "Chromatic 1-2-3-4", Permutations: PermutationsChromatic, StartFret: 1,
+		Strings: StringsAdjacent, Direction: DirectionUpDown, Picking: "d u", Shift: 1, Repeats: 4},
+	{Name: "Chromatic 1-3-2-4", Permutations: Permutations1324, StartFret: 5,
+		Strings: StringsAdjacent, Direction: DirectionUpDown, Picking: "d u", Shift: 1, Repeats: 4},
+	{Name: "Spider Permutations", Permutations: PermutationsSpider, StartFret: 5,
+		Strings: StringsAdjacent, Direction: DirectionUp, Picking: "d u", Repeats: 4},
+	{Name: "String-Skipping Chromatic", Permutations: PermutationsChromatic, StartFret: 5,
+		Strings: StringsSkipping, Direction: DirectionUpDown, Picking: "d u", Shift: -1, Repeats: 3},
+	{Name: "Chromatic Downstrokes", Permutations: PermutationsChromatic, StartFret: 7,
+		Strings: StringsAdjacent, Direction: DirectionDown, Picking: "d", Shift: 1, Repeats: 2},
+}
+
+// GenerateExercise builds the exercise as a lesson, one note per beat.
+// Passes that would leave the neck are dropped.
+func GenerateExercise(c ExerciseConfig) Lesson {
+	l := Lesson{
+		Title:    "Exercise: " + c.Name,
+		Category: "exercise",
+		BPM:      ExerciseBPM,
+	}
+
+	strs := append([]int(nil), c.Strings...)
+	var reversed []int
+	for i := len(c.Strings) - 1; i >= 0; i-- {
+		reversed = append(reversed, c.Strings[i])
+	}
+	switch c.Direction {
+	case DirectionDown:
+		strs = reversed
+	case DirectionUpDown:
+		strs = append(strs, reversed[1:]...)
+	}
+	down := func(i int) bool {
+		return c.Direction == DirectionDown || c.Direction == DirectionUpDown && i >= len(c.Strings)
+	}
+
+	strokes := strings.Fields(c.Picking)
+	perm := 0
+	for pass := 0; pass < max(c.Repeats, 1); pass++ {
+		fret := c.StartFret + pass*c.Shift
+		if fret < 1 || fret+3 > NeckFrets {
+			break
+		}
+		for i, s := range strs {
+			if s >= len(theory.StandardTuning) || len(c.Permutations) == 0 {
+				continue
+			}
+			fingers := c.Permutations[perm%len(c.Permutations)]
+			perm++
+			for k := range fingers {
+				finger := fingers[k]
+				if down(i) {
+					finger = fingers[len(fingers)-1-k]
+				}
+				m := Marker{StringIndex: s, Fret: fret + finger - 1, Finger: finger}
+				if len(strokes) > 0 {
+					m.Picking = strokePicking(strokes[len(l.Steps)%len(strokes)])
+				}
+				l.Steps = append(l.Steps, Step{Beat: len(l.Steps) + 1, Markers: []Marker{m}})
+			}
+		}
+	}
+
+	l.RecalculateNotes()
+	if len(l.Steps) > 0 {
+		l.ActualKey = l.Steps[0].Markers[0].Note
+	}
+	return l
+}
+
+// GeneratedExercises builds every preset
+func GeneratedExercises() []Lesson {
+	lessons := make([]Lesson, len(ExercisePresets))
+	for i, c := range ExercisePresets {
+		lessons[i] = GenerateExercise(c)
+	}
+	return lessons
+}
+
+// strokePicking reads one stroke of a picking pattern: "d", "u"
+func strokePicking(stroke string) PickingType {
+	switch stroke {
+	case "d":
+		return PickDown
+	case "u":
+		return PickUp
+	}
+	return PickNone
+}
+
+// fingerPermutations lists every order of the fingers, in lexical order
+// starting with the fingers as given: 1234, 1243, 1324...
+func fingerPermutations(fingers []int) [][]int {
+	if len(fingers) <= 1 {
+		return [][]int{append([]int(nil), fingers...)}
+	}
+	var perms [][]int
+	for i, f := range fingers {
+		rest := append(append([]int(nil), fingers[:i]...), fingers[i+1:]...)
+		for _, p := range fingerPermutations(rest) {
+			perms = append(perms, append([]int{f}, p...))
+		}
+	}
+	return perms
+}
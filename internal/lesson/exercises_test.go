@@ -0,0 +1,77 @@
+package lesson
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// exerciseNotes lists the exercise as "string:fret" per beat (1 = high e)
+func exerciseNotes(l Lesson) []string {
+	var notes []string
+	for _, step := range l.Steps {
+		m := step.Markers[0]
+		notes = append(notes, fmt.Sprintf("%d:%d", 6-m.StringIndex, m.Fret))
+	}
+	return notes
+}
+
+func TestGenerateExercise(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ExerciseConfig
+		want   []string
+	}{
+		{"up", ExerciseConfig{Permutations: PermutationsChromatic, StartFret: 1, Strings: []int{0, 1}, Direction: DirectionUp},
+			[]string{"6:1", "6:2", "6:3", "6:4", "5:1", "5:2", "5:3", "5:4"}},
+		{"down: fingers reversed", ExerciseConfig{Permutations: PermutationsChromatic, StartFret: 5, Strings: []int{0, 1}, Direction: DirectionDown},
+			[]string{"5:8", "5:7", "5:6", "5:5", "6:8", "6:7", "6:6", "6:5"}},
+		{"up-down turns on the top string", ExerciseConfig{Permutations: Permutations1324, StartFret: 1, Strings: []int{4, 5}, Direction: DirectionUpDown},
+			[]string{"2:1", "2:3", "2:2", "2:4", "1:1", "1:3", "1:2", "1:4", "2:4", "2:2", "2:3", "2:1"}},
+		{"shift per pass", ExerciseConfig{Permutations: [][]int{{1, 4}}, StartFret: 3, Strings: []int{0}, Direction: DirectionUp, Shift: 2, Repeats: 3},
+			[]string{"6:3", "6:6", "6:5", "6:8", "6:7", "6:10"}},
+		{"passes off the neck are dropped", ExerciseConfig{Permutations: [][]int{{1}}, StartFret: 2, Strings: []int{0}, Direction: DirectionUp, Shift: -1, Repeats: 3},
+			[]string{"6:2", "6:1"}},
+	}
+	for _, tt := range tests {
+		if got := exerciseNotes(GenerateExercise(tt.config)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExercisePresets(t *testing.T) {
+	for _, l := range GeneratedExercises() {
+		if len(l.Steps) == 0 {
+			t.Errorf("%s: empty", l.Title)
+			continue
+		}
+		for _, step := range l.Steps {
+			m := step.Markers[0]
+			if m.Fret < 1 || m.Fret > NeckFrets || m.Finger < 1 || m.Finger > 4 {
+				t.Errorf("%s beat %d: fret %d finger %d", l.Title, step.Beat, m.Fret, m.Finger)
+			}
+			if m.Picking == PickNone {
+				t.Errorf("%s beat %d: no stroke", l.Title, step.Beat)
+			}
+		}
+		if issues := CheckFingering(l); len(issues) > 0 {
+			t.Errorf("%s: %v", l.Title, issues[0])
+		}
+	}
+}
+
+func TestFingerPermutations(t *testing.T) {
+	perms := PermutationsSpider
+	if len(perms) != 24 || !slices.Equal(perms[0], []int{1, 2, 3, 4}) || !slices.Equal(perms[1], []int{1, 2, 4, 3}) ||
+		!slices.Equal(perms[23], []int{4, 3, 2, 1}) {
+		t.Errorf("%d permutations: %v ... %v", len(perms), perms[:2], perms[len(perms)-1])
+	}
+	seen := make(map[string]bool)
+	for _, p := range perms {
+		seen[fmt.Sprint(p)] = true
+	}
+	if len(seen) != 24 {
+		t.Errorf("%d distinct orders, want 24", len(seen))
+	}
+}
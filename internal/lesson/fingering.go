@@ -0,0 +1,281 @@
+package lesson
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+const (
+	// maxHandPosition is the highest fret the index finger is placed on
+	maxHandPosition = 24
+
+	shiftBaseCost  = 1.0  // any position change
+	shiftFretCost  = 0.5  // per fret moved
+	slideShiftCost = 0.2  // shifting along with a slide is nearly free
+	stretchCost    = 1.5  // finger 1 reaching back or finger 4 reaching forward
+	pinkyCost      = 0.1  // slight preference for fingers 1-3
+	explicitCost   = 50.0 // position that contradicts an explicit (fN)
+)
+
+var infiniteCost = math.Inf(1)
+
+// fingerAt returns the finger that plays fret when the index finger is on
+// handPos, plus the extra stretch cost. ok is false when out of reach.
+func fingerAt(handPos, fret int) (finger int, cost float64, ok bool) {
+	switch d := fret - handPos; {
+	case d >= 0 && d <= 3:
+		return d + 1, 0, true
+	case d == 4:
+		return 4, stretchCost, true
+	case d == -1:
+		return 1, stretchCost, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// fingerTarget is one fret the hand must reach in a step
+type fingerTarget struct {
+	fret     int
+	explicit int // finger written in the tab, 0 = free
+}
+
+// AssignFingers fills Marker.Finger for fretted notes that have none (finger 0)
+// by choosing a hand position per step that minimizes hand movement and
+// stretching over the whole lesson. Slides carry the hand to their target fret.
+// Explicit fingers are never changed; they pin the hand position instead.
+// Returns the number of fingers assigned.
+func AssignFingers(l *Lesson) int {
+	n := len(l.Steps)
+	if n == 0 {
+		return 0
+	}
+
+	targets := fingeringTargets(l)
+
+	// Viterbi over hand positions 1..maxHandPosition
+	cost := make([][]float64, n)
+	from := make([][]int, n)
+	for i := range cost {
+		cost[i] = make([]float64, maxHandPosition+1)
+		from[i] = make([]int, maxHandPosition+1)
+	}
+
+	for p := 1; p <= maxHandPosition; p++ {
+		cost[0][p] = stepCost(targets[0], p)
+	}
+
+	for i := 1; i < n; i++ {
+		slide := slideDelta(l.Steps[i-1])
+		for p := 1; p <= maxHandPosition; p++ {
+			here := stepCost(targets[i], p)
+			cost[i][p] = infiniteCost
+			if math.IsInf(here, 1) {
+				continue
+			}
+			for q := 1; q <= maxHandPosition; q++ {
+				if math.IsInf(cost[i-1][q], 1) {
+					continue
+				}
+				c := cost[i-1][q] + shiftCost(q, p, slide, len(targets[i]) == 0) + here
+				if c < cost[i][p] {
+					cost[i][p] = c
+					from[i][p] = q
+				}
+			}
+		}
+	}
+
+	// Backtrack the cheapest path
+	path := make([]int, n)
+	best := infiniteCost
+	for p := 1; p <= maxHandPosition; p++ {
+		if cost[n-1][p] < best {
+			best = cost[n-1][p]
+			path[n-1] = p
+		}
+	}
+	if math.IsInf(best, 1) {
+		return 0
+	}
+	for i := n - 1; i > 0; i-- {
+		path[i-1] = from[i][path[i]]
+	}
+
+	assigned := 0
+	for i := range l.Steps {
+		for k := range l.Steps[i].Markers {
+			m := &l.Steps[i].Markers[k]
+			if m.Finger != 0 || !usesFretHand(*m) {
+				continue
+			}
+			if finger, _, ok := fingerAt(path[i], m.Fret); ok {
+				m.Finger = finger
+				assigned++
+			}
+		}
+	}
+	return assigned
+}
+
+// fingeringTargets lists, per step, the frets the hand holds: the step's own
+// fretted notes, legato targets and notes still ringing from earlier steps
+func fingeringTargets(l *Lesson) [][]fingerTarget {
+	targets := make([][]fingerTarget, len(l.Steps))
+
+	for i, step := range l.Steps {
+		for _, m := range step.Markers {
+			if !usesFretHand(m) {
+				continue
+			}
+			targets[i] = append(targets[i], fingerTarget{fret: m.Fret, explicit: m.Finger})
+
+			switch m.Technique {
+			case TechHammer, TechPullOff, TechTrill:
+				if m.TechParams.TargetFret > 0 {
+					targets[i] = append(targets[i], fingerTarget{fret: m.TechParams.TargetFret})
+				}
+			}
+
+			// Held notes keep their finger down on the following beats
+			for j := i + 1; j < len(l.Steps) && l.Steps[j].Beat < step.Beat+m.Duration; j++ {
+				targets[j] = append(targets[j], fingerTarget{fret: m.Fret, explicit: m.Finger})
+			}
+		}
+	}
+
+	return targets
+}
+
+// stepCost is the cost of playing a step's targets with the index finger on handPos
+func stepCost(targets []fingerTarget, handPos int) float64 {
+	total := 0.0
+	used := map[int]int{} // finger -> fret
+
+	for _, t := range targets {
+		finger, c, ok := fingerAt(handPos, t.fret)
+		if !ok {
+			return infiniteCost
+		}
+		if t.explicit > 0 && t.explicit != finger {
+			c += explicitCost
+			finger = t.explicit
+		}
+		// One finger can't hold two frets (same fret on several strings is a barre)
+		if f, taken := used[finger]; taken && f != t.fret {
+			return infiniteCost
+		}
+		used[finger] = t.fret
+		if finger == 4 {
+			c += pinkyCost
+		}
+		total += c
+	}
+
+	return total
+}
+
+// shiftCost is the cost of moving the hand from position q to p.
+// slide is how far a slide in the previous step already carried the hand.
+func shiftCost(q, p, slide int, restingStep bool) float64 {
+	if p == q+slide && slide != 0 {
+		return slideShiftCost
+	}
+	if p == q {
+		return 0
+	}
+	c := shiftBaseCost + shiftFretCost*float64(absInt(p-q))
+	if restingStep {
+		// Moving during an open string or rest is easier
+		c /= 2
+	}
+	return c
+}
+
+// slideDelta returns how many frets a slide in the step moves the hand
+func slideDelta(step Step) int {
+	for _, m := range step.Markers {
+		if m.Technique == TechSlide && m.TechParams.TargetFret > 0 {
+			return m.TechParams.TargetFret - m.Fret
+		}
+	}
+	return 0
+}
+
+// WriteFingers writes the lesson's fingers back into its .tab file as (fN),
+// for every fretted note whose cell has no finger yet. Other content is kept as is.
+func WriteFingers(path string, l Lesson) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read tab file: %w", err)
+	}
+	lines := strings.Split(string(data), "\n")
+
+	parser := newTabParser()
+	for lineNo, line := range lines {
+		if !parser.scanLine(line, lineNo) {
+			break
+		}
+	}
+	parser.parseSteps()
+
+	stepByBeat := make(map[int]Step)
+	for _, step := range l.Steps {
+		stepByBeat[step.Beat] = step
+	}
+
+	for stringIdx, lineNo := range parser.lineNumbers {
+		line := lines[lineNo]
+		head := strings.Index(line, "|")
+		content := line[head+1:]
+		hasClosing := strings.HasSuffix(content, "|")
+		content = strings.TrimSuffix(content, "|")
+
+		cells := strings.Split(content, "|")
+		for col, cellIdx := range beatCellIndexes(cells) {
+			if col >= len(parser.columnBeats) {
+				break
+			}
+			step, ok := stepByBeat[parser.columnBeats[col]]
+			if !ok {
+				continue
+			}
+			for _, m := range step.Markers {
+				if m.StringIndex == stringIdx && m.Finger > 0 && usesFretHand(m) {
+					cells[cellIdx] = withFinger(cells[cellIdx], m.Finger)
+				}
+			}
+		}
+
+		content = strings.Join(cells, "|")
+		if hasClosing {
+			content += "|"
+		}
+		lines[lineNo] = line[:head+1] + content
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return fmt.Errorf("cannot write tab file: %w", err)
+	}
+	return nil
+}
+
+// withFinger adds (fN) to a raw tab cell unless it already names a finger.
+// "--5h7--" -> "--5h7(f1)--", "5(d)" -> "5(f1:d)"
+func withFinger(cell string, finger int) string {
+	if open := strings.Index(cell, "("); open != -1 {
+		inner := cell[open+1:]
+		if strings.HasPrefix(inner, "f") || (len(inner) > 1 && inner[0] >= '0' && inner[0] <= '9' && inner[1] == ')') {
+			return cell
+		}
+		return fmt.Sprintf("%s(f%d:%s", cell[:open], finger, inner)
+	}
+
+	end := strings.LastIndexFunc(cell, func(r rune) bool { return r != '-' && r != ' ' })
+	if end == -1 {
+		return cell
+	}
+	return fmt.Sprintf("%s(f%d)%s", cell[:end+1], finger, cell[end+1:])
+}
@@ -0,0 +1,109 @@
+package lesson
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fingers lists the finger of every marker, step by step
+func fingers(l Lesson) []int {
+	var f []int
+	for _, step := range l.Steps {
+		for _, m := range step.Markers {
+			f = append(f, m.Finger)
+		}
+	}
+	return f
+}
+
+// frets makes a lesson of single notes on one string each: {string, fret}
+func frets(notes ...[2]int) Lesson {
+	var l Lesson
+	for i, n := range notes {
+		l.Steps = append(l.Steps, Step{Beat: i + 1, Markers: []Marker{{StringIndex: n[0], Fret: n[1], Duration: 1}}})
+	}
+	return l
+}
+
+func TestAssignFingers(t *testing.T) {
+	tests := []struct {
+		name string
+		l    Lesson
+		want []int
+	}{
+		{"pentatonic box", frets([2]int{0, 5}, [2]int{0, 8}, [2]int{1, 5}, [2]int{1, 7}, [2]int{2, 5}, [2]int{2, 7}),
+			[]int{1, 4, 1, 3, 1, 3}},
+		{"open strings stay open", frets([2]int{0, 0}, [2]int{0, 3}, [2]int{1, 0}, [2]int{1, 2}),
+			[]int{0, 3, 0, 2}},
+		{"position shift", frets([2]int{0, 1}, [2]int{0, 3}, [2]int{0, 12}, [2]int{0, 14}, [2]int{0, 15}),
+			[]int{1, 3, 1, 3, 4}},
+	}
+	for _, tt := range tests {
+		AssignFingers(&tt.l)
+		if got := fingers(tt.l); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: fingers %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// An explicit finger is kept and moves the hand: fret 7 with finger 1
+	// puts fret 9 under the 3rd finger, not the 1st of a shifted hand
+	l := frets([2]int{0, 7}, [2]int{0, 9})
+	l.Steps[0].Markers[0].Finger = 1
+	if n := AssignFingers(&l); n != 1 {
+		t.Errorf("assigned %d fingers, want 1", n)
+	}
+	if got := fingers(l); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("pinned: fingers %v, want [1 3]", got)
+	}
+}
+
+func TestWithFinger(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"--5h7--", "--5h7(f1)--"},
+		{"5(d)", "5(f1:d)"},
+		{"5(f2)", "5(f2)"}, // Already fingered
+		{"5(4)", "5(4)"},   // Hold duration, not a finger spec
+		{"7(2:u)", "7(f1:2:u)"},
+		{"---", "---"},
+	}
+	for _, tt := range tests {
+		if got := withFinger(tt.cell, 1); got != tt.want {
+			t.Errorf("withFinger(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFingers(t *testing.T) {
+	tab := strings.Join([]string{
+		"TITLE: Write back",
+		"e|-|-|-|",
+		"B|-|-|-|",
+		"G|-|-|-|",
+		"D|-|-|-|",
+		"A|-|-|-|",
+		"E|5|8(f4)|0|",
+		"",
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "write.tab")
+	if err := os.WriteFile(path, []byte(tab), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := LoadTabFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssignFingers(l)
+	if err := WriteFingers(path, *l); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "E|5(f1)|8(f4)|0|"; !strings.Contains(string(data), want) {
+		t.Errorf("low E line not %q in:\n%s", want, data)
+	}
+}
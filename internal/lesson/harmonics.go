@@ -0,0 +1,91 @@
+package lesson
+
+import (
+	"regexp"
+	"strconv"
+
+	"guitui/internal/theory"
+)
+
+const (
+	HarmonicNatural    = "natural"    // <12>, NH12: open string touched at the node
+	HarmonicArtificial = "artificial" // AH17, 5AH17: fretted note touched at the node
+)
+
+var (
+	naturalHarmonicPattern    = regexp.MustCompile(`^(?:<(\d+(?:\.\d+)?)>|NH(\d+(?:\.\d+)?))`)
+	artificialHarmonicPattern = regexp.MustCompile(`^(\d*)AH(\d+(?:\.\d+)?)`)
+)
+
+// parseHarmonicCell parses harmonic cells: "<7>", "<3.2>", "NH12", "AH17"
+// (fret 17 touched an octave up) and "5AH17" (fret 5 touched at fret 17).
+// Returns nil if the cell is not a harmonic.
+func (p *TabParser) parseHarmonicCell(stringIdx int, cell string) *Marker {
+	m := Marker{StringIndex: stringIdx, Technique: TechHarmonic}
+	var rest string
+
+	if match := naturalHarmonicPattern.FindStringSubmatch(cell); match != nil {
+		nodeStr := match[1] + match[2]
+		node, _ := strconv.ParseFloat(nodeStr, 64)
+		m.Fret = int(node) // Drawn at the fret nearest the nut
+		m.TechParams.HarmonicNode = node
+		m.TechParams.HarmonicType = HarmonicNatural
+		rest = cell[len(match[0]):]
+	} else if match := artificialHarmonicPattern.FindStringSubmatch(cell); match != nil {
+		node, _ := strconv.ParseFloat(match[2], 64)
+		fret := int(node)
+		if match[1] != "" {
+			fret, _ = strconv.Atoi(match[1])
+		} else {
+			// "AH17": fret 17, touched an octave up
+			node += 12
+		}
+		m.Fret = fret
+		m.TechParams.HarmonicNode = node
+		m.TechParams.HarmonicType = HarmonicArtificial
+		rest = cell[len(match[0]):]
+	} else {
+		return nil
+	}
+
+	_, m.Finger = p.extractFretFinger(rest)
+	m.Picking = p.extractPicking(rest)
+	return &m
+}
+
+// harmonicShift returns the fret (tab, capo-relative) the harmonic is stopped
+// at and how many semitones above it the harmonic sounds. ok is false for
+// non-harmonics and for nodes that don't ring a clear partial.
+func harmonicShift(m Marker) (stopped, semitones int, ok bool) {
+	if m.Technique != TechHarmonic {
+		return 0, 0, false
+	}
+
+	if m.TechParams.HarmonicType == HarmonicArtificial {
+		stopped = m.Fret
+	}
+
+	partial, ok := theory.HarmonicPartial(m.harmonicNode() - float64(stopped))
+	if !ok {
+		return 0, 0, false
+	}
+	return stopped, theory.HarmonicInterval(partial), true
+}
+
+// HarmonicPartial returns the partial a harmonic marker rings (2 = octave), 0 if none
+func (m Marker) HarmonicPartial() int {
+	stopped, _, ok := harmonicShift(m)
+	if !ok {
+		return 0
+	}
+	partial, _ := theory.HarmonicPartial(m.harmonicNode() - float64(stopped))
+	return partial
+}
+
+// harmonicNode is the touch point; JSON lessons only give the fret
+func (m Marker) harmonicNode() float64 {
+	if m.TechParams.HarmonicNode == 0 {
+		return float64(m.Fret)
+	}
+	return m.TechParams.HarmonicNode
+}
@@ -0,0 +1,43 @@
+package lesson
+
+import (
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestHarmonicCells(t *testing.T) {
+	tests := []struct {
+		cell  string
+		kind  string
+		fret  int
+		node  float64
+		pitch theory.Pitch // On the low E string
+	}{
+		{"<12>", HarmonicNatural, 12, 12, theory.NewPitch(theory.E, 3)},
+		{"NH7", HarmonicNatural, 7, 7, theory.NewPitch(theory.B, 3)},
+		{"<5>", HarmonicNatural, 5, 5, theory.NewPitch(theory.E, 4)},
+		{"<3.2>", HarmonicNatural, 3, 3.2, theory.NewPitch(theory.B, 4)},
+		{"5AH17", HarmonicArtificial, 5, 17, theory.NewPitch(theory.A, 3)},
+		{"AH17", HarmonicArtificial, 17, 29, theory.NewPitch(theory.A, 4)},
+	}
+	for _, tt := range tests {
+		m := newTabParser().parseHarmonicCell(0, tt.cell)
+		if m == nil {
+			t.Errorf("%s: not a harmonic", tt.cell)
+			continue
+		}
+		if m.TechParams.HarmonicType != tt.kind || m.Fret != tt.fret || m.TechParams.HarmonicNode != tt.node {
+			t.Errorf("%s: %s fret %d node %g, want %s fret %d node %g", tt.cell,
+				m.TechParams.HarmonicType, m.Fret, m.TechParams.HarmonicNode, tt.kind, tt.fret, tt.node)
+		}
+		l := Lesson{Steps: []Step{{Beat: 1, Markers: []Marker{*m}}}}
+		l.RecalculateNotes()
+		if got := l.Steps[0].Markers[0].Pitch; got != tt.pitch {
+			t.Errorf("%s sounds %s, want %s", tt.cell, got, tt.pitch)
+		}
+	}
+	if m := newTabParser().parseHarmonicCell(0, "12"); m != nil {
+		t.Errorf("plain fret parsed as a harmonic: %+v", m)
+	}
+}
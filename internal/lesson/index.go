@@ -0,0 +1,337 @@
+package lesson
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LessonIndex summarizes the content of a lesson: which techniques and
+// picking types it uses, where it sits on the neck and how long it lasts.
+type LessonIndex struct {
+	Techniques map[TechniqueType]int // technique -> number of markers
+	Pickings   map[PickingType]int   // picking type -> number of markers
+	Frets      []int                 // sorted unique frets (0 = open string)
+	Strings    []int                 // sorted unique string indexes (0 = low E)
+
+	MinFret   int // lowest fretted note (open strings excluded), -1 if none
+	MaxFret   int // highest fretted note, -1 if none
+	FretSpan  int // MaxFret - MinFret + 1, 0 if no fretted notes
+	NoteCount int // played notes (muted notes excluded)
+	Beats     int // total beats including holds and rests
+	Duration  time.Duration
+}
+
+// BuildIndex walks every step of the lesson and computes its index
+func BuildIndex(l Lesson) LessonIndex {
+	ix := LessonIndex{
+		Techniques: make(map[TechniqueType]int),
+		Pickings:   make(map[PickingType]int),
+		MinFret:    -1,
+		MaxFret:    -1,
+	}
+
+	frets := make(map[int]bool)
+	strs := make(map[int]bool)
+
+	for _, step := range l.Steps {
+		for _, m := range step.Markers {
+			if m.Technique != TechNone {
+				ix.Techniques[m.Technique]++
+			}
+			if m.Picking != PickNone {
+				ix.Pickings[m.Picking]++
+			}
+
+			// Muted notes (x) have no pitch and no position
+			if m.Fret < 0 {
+				continue
+			}
+			ix.NoteCount++
+			frets[m.Fret] = true
+			strs[m.StringIndex] = true
+
+			if m.Fret > 0 {
+				if ix.MinFret < 0 || m.Fret < ix.MinFret {
+					ix.MinFret = m.Fret
+				}
+				if m.Fret > ix.MaxFret {
+					ix.MaxFret = m.Fret
+				}
+			}
+
+			// Notes held past the last step still count toward the length
+			duration := m.Duration
+			if duration <= 0 {
+				duration = 1
+			}
+			if end := step.Beat + duration - 1; end > ix.Beats {
+				ix.Beats = end
+			}
+		}
+		if step.Beat > ix.Beats {
+			ix.Beats = step.Beat
+		}
+	}
+
+	ix.Frets = sortedKeys(frets)
+	ix.Strings = sortedKeys(strs)
+	if ix.MinFret >= 0 {
+		ix.FretSpan = ix.MaxFret - ix.MinFret + 1
+	}
+	if l.BPM > 0 {
+		ix.Duration = time.Duration(ix.Beats) * time.Minute / time.Duration(l.BPM)
+	}
+
+	return ix
+}
+
+// HasTechnique reports whether any marker uses the technique
+func (ix LessonIndex) HasTechnique(t TechniqueType) bool {
+	return ix.Techniques[t] > 0
+}
+
+// HasPicking reports whether any marker uses the picking type
+func (ix LessonIndex) HasPicking(p PickingType) bool {
+	return ix.Pickings[p] > 0
+}
+
+// HasString reports whether any note is played on the string index
+func (ix LessonIndex) HasString(stringIdx int) bool {
+	for _, s := range ix.Strings {
+		if s == stringIdx {
+			return true
+		}
+	}
+	return false
+}
+
+// Tags returns the technique and picking names used, sorted
+func (ix LessonIndex) Tags() []string {
+	var tags []string
+	for t, n := range ix.Techniques {
+		if n > 0 {
+			tags = append(tags, string(t))
+		}
+	}
+	for p, n := range ix.Pickings {
+		if n > 0 {
+			tags = append(tags, string(p))
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Summary returns a short one-line description, e.g. "bend, sweep • fr 5-8 • 24 notes • 0:32"
+func (ix LessonIndex) Summary() string {
+	var parts []string
+	if tags := ix.Tags(); len(tags) > 0 {
+		parts = append(parts, strings.Join(tags, ", "))
+	}
+	if ix.MinFret >= 0 {
+		parts = append(parts, fmt.Sprintf("fr %d-%d", ix.MinFret, ix.MaxFret))
+	}
+	parts = append(parts, fmt.Sprintf("%d notes", ix.NoteCount))
+	if ix.Duration > 0 {
+		secs := int(ix.Duration.Round(time.Second) / time.Second)
+		parts = append(parts, fmt.Sprintf("%d:%02d", secs/60, secs%60))
+	}
+	return strings.Join(parts, " • ")
+}
+
+func sortedKeys(set map[int]bool) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// --- QUERY ---
+
+// Query filters lessons by their index. All conditions must match.
+type Query struct {
+	Techniques []TechniqueType
+	Pickings   []PickingType
+	Strings    []int    // string indexes that must be used
+	MinFret    int      // all fretted notes must be >= MinFret (-1 = no limit)
+	MaxFret    int      // all fretted notes must be <= MaxFret (-1 = no limit)
+	MaxSpan    int      // fret span limit (0 = no limit)
+	Words      []string // free text matched against title and category
+}
+
+// Query keywords -> technique
+var techniqueWords = map[string]TechniqueType{
+	"bend":     TechBend,
+	"prebend":  TechPreBend,
+	"slide":    TechSlide,
+	"hammer":   TechHammer,
+	"hammeron": TechHammer,
+	"pull":     TechPullOff,
+	"pulloff":  TechPullOff,
+	"vibrato":  TechVibrato,
+	"tap":      TechTap,
+	"tapping":  TechTap,
+	"harmonic": TechHarmonic,
+	"natural":  TechHarmonic,
+	"pinch":    TechPinch,
+	"trill":    TechTrill,
+}
+
+// Query keywords -> picking
+var pickingWords = map[string]PickingType{
+	"down":       PickDown,
+	"downstroke": PickDown,
+	"up":         PickUp,
+	"upstroke":   PickUp,
+	"alternate":  PickAlternate,
+	"tremolo":    PickTremolo,
+	"sweep":      PickSweep,
+	"economy":    PickEconomy,
+}
+
+// Filler words ignored in natural queries ("lessons with pinch harmonics and sweep picking")
+var queryStopWords = map[string]bool{
+	"show": true, "me": true, "every": true, "all": true, "lesson": true, "with": true,
+	"and": true, "the": true, "a": true, "picking": true, "pick": true, "on": true, "using": true,
+}
+
+// ParseQuery parses a free-form query. Recognized terms:
+//
+//	technique / picking names   bend, pinch harmonic, sweep, hammer-on, ...
+//	string:N                    uses string N (1 = high e, 6 = low E)
+//	frets:A-B                   every fretted note is within frets A..B
+//	span<=N                     fret span at most N
+//
+// Anything else is matched as text against title and category.
+func ParseQuery(s string) Query {
+	q := Query{MinFret: -1, MaxFret: -1}
+
+	tokens := strings.Fields(strings.ToLower(s))
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		switch {
+		case strings.HasPrefix(tok, "string:"):
+			if n, err := strconv.Atoi(strings.TrimPrefix(tok, "string:")); err == nil && n >= 1 && n <= 6 {
+				q.Strings = append(q.Strings, 6-n)
+			}
+			continue
+		case strings.HasPrefix(tok, "frets:"):
+			lo, hi, ok := parseRange(strings.TrimPrefix(tok, "frets:"))
+			if ok {
+				q.MinFret, q.MaxFret = lo, hi
+			}
+			continue
+		case strings.HasPrefix(tok, "span<="):
+			if n, err := strconv.Atoi(strings.TrimPrefix(tok, "span<=")); err == nil {
+				q.MaxSpan = n
+			}
+			continue
+		}
+
+		word := normalizeQueryWord(tok)
+		if queryStopWords[word] {
+			continue
+		}
+
+		// "pinch harmonic" / "natural harmonic": the second word is part of the first
+		if (word == "pinch" || word == "natural") && i+1 < len(tokens) && normalizeQueryWord(tokens[i+1]) == "harmonic" {
+			i++
+		}
+
+		if t, ok := techniqueWords[word]; ok {
+			q.Techniques = append(q.Techniques, t)
+		} else if p, ok := pickingWords[word]; ok {
+			q.Pickings = append(q.Pickings, p)
+		} else {
+			q.Words = append(q.Words, tok)
+		}
+	}
+
+	return q
+}
+
+// normalizeQueryWord lowercases, removes dashes and plural "s": "Hammer-ons" -> "hammeron"
+func normalizeQueryWord(w string) string {
+	w = strings.ToLower(strings.ReplaceAll(w, "-", ""))
+	if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+		w = strings.TrimSuffix(w, "s")
+	}
+	return w
+}
+
+func parseRange(s string) (lo, hi int, ok bool) {
+	parts := strings.SplitN(s, "-", 2)
+	lo, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	hi = lo
+	if len(parts) == 2 {
+		if hi, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, false
+		}
+	}
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	return lo, hi, true
+}
+
+// IsEmpty reports whether the query has no conditions
+func (q Query) IsEmpty() bool {
+	return len(q.Techniques) == 0 && len(q.Pickings) == 0 && len(q.Strings) == 0 &&
+		q.MinFret < 0 && q.MaxFret < 0 && q.MaxSpan == 0 && len(q.Words) == 0
+}
+
+// Match reports whether a lesson (with its precomputed index) satisfies the query
+func (q Query) Match(l Lesson, ix LessonIndex) bool {
+	for _, t := range q.Techniques {
+		if !ix.HasTechnique(t) {
+			return false
+		}
+	}
+	for _, p := range q.Pickings {
+		if !ix.HasPicking(p) {
+			return false
+		}
+	}
+	for _, s := range q.Strings {
+		if !ix.HasString(s) {
+			return false
+		}
+	}
+	if q.MinFret >= 0 && ix.MinFret >= 0 && ix.MinFret < q.MinFret {
+		return false
+	}
+	if q.MaxFret >= 0 && ix.MaxFret > q.MaxFret {
+		return false
+	}
+	if q.MaxSpan > 0 && ix.FretSpan > q.MaxSpan {
+		return false
+	}
+
+	text := strings.ToLower(l.Title + " " + l.Category)
+	for _, w := range q.Words {
+		if !strings.Contains(text, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterLessons returns the lessons matching the query, in their original order
+func FilterLessons(lessons []Lesson, q Query) []Lesson {
+	var result []Lesson
+	for _, l := range lessons {
+		if q.Match(l, BuildIndex(l)) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package lesson
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBuildIndex(t *testing.T) {
+	l := Lesson{
+		BPM: 120,
+		Steps: []Step{
+			{Beat: 1, Markers: []Marker{{StringIndex: 5, Fret: 0}, {StringIndex: 4, Fret: 5, Picking: PickDown}}},
+			{Beat: 2, Markers: []Marker{{StringIndex: 4, Fret: 7, Technique: TechBend}}},
+			{Beat: 3, Markers: []Marker{{StringIndex: 3, Fret: -1}}}, // Muted
+			{Beat: 4, Markers: []Marker{{StringIndex: 3, Fret: 9, Duration: 4, Technique: TechVibrato}}},
+		},
+	}
+	ix := BuildIndex(l)
+
+	if ix.MinFret != 5 || ix.MaxFret != 9 || ix.FretSpan != 5 {
+		t.Errorf("frets %d-%d span %d, want 5-9 span 5", ix.MinFret, ix.MaxFret, ix.FretSpan)
+	}
+	if !slices.Equal(ix.Frets, []int{0, 5, 7, 9}) || !slices.Equal(ix.Strings, []int{3, 4, 5}) {
+		t.Errorf("frets %v strings %v", ix.Frets, ix.Strings)
+	}
+	if ix.NoteCount != 4 {
+		t.Errorf("%d notes, want 4 (the muted one has no pitch)", ix.NoteCount)
+	}
+	// The last note rings from beat 4 through 7: 7 beats at 120 BPM
+	if ix.Beats != 7 || ix.Duration != 3500*time.Millisecond {
+		t.Errorf("%d beats, %v", ix.Beats, ix.Duration)
+	}
+	if got := ix.Summary(); got != "bend, down, vibrato • fr 5-9 • 4 notes • 0:04" {
+		t.Errorf("summary %q", got)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Query
+	}{
+		{"", Query{MinFret: -1, MaxFret: -1}},
+		{"lessons with pinch harmonics and sweep picking",
+			Query{Techniques: []TechniqueType{TechPinch}, Pickings: []PickingType{PickSweep}, MinFret: -1, MaxFret: -1}},
+		{"Hammer-ons string:1 frets:12-5 span<=4",
+			Query{Techniques: []TechniqueType{TechHammer}, Strings: []int{5}, MinFret: 5, MaxFret: 12, MaxSpan: 4}},
+		{"blues string:7 frets:x", Query{MinFret: -1, MaxFret: -1, Words: []string{"blues"}}},
+	}
+	for _, tt := range tests {
+		got := ParseQuery(tt.query)
+		if !slices.Equal(got.Techniques, tt.want.Techniques) || !slices.Equal(got.Pickings, tt.want.Pickings) ||
+			!slices.Equal(got.Strings, tt.want.Strings) || !slices.Equal(got.Words, tt.want.Words) ||
+			got.MinFret != tt.want.MinFret || got.MaxFret != tt.want.MaxFret || got.MaxSpan != tt.want.MaxSpan {
+			t.Errorf("ParseQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFilterLessons(t *testing.T) {
+	bends := Lesson{Title: "Blues bends", Steps: []Step{
+		{Beat: 1, Markers: []Marker{{StringIndex: 3, Fret: 7, Technique: TechBend}}},
+	}}
+	open := Lesson{Title: "Open strings", Category: "Warm-up", Steps: []Step{
+		{Beat: 1, Markers: []Marker{{StringIndex: 0, Fret: 0}, {StringIndex: 5, Fret: 0}}},
+	}}
+	lessons := []Lesson{bends, open}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"bend", []string{"Blues bends"}},
+		{"warm-up", []string{"Open strings"}},
+		{"string:6", []string{"Open strings"}},
+		{"frets:0-5", []string{"Open strings"}}, // Open strings are not fretted
+		{"frets:5-9 span<=1", []string{"Blues bends", "Open strings"}},
+		{"sweep", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, l := range FilterLessons(lessons, ParseQuery(tt.query)) {
+			got = append(got, l.Title)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q matched %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
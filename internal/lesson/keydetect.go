@@ -0,0 +1,57 @@
+package lesson
+
+import (
+	"guitui/internal/theory"
+)
+
+// Weighting of notes for key detection
+const (
+	// barBeats is the bar length assumed for strong beats (lessons have no time signature)
+	barBeats = 4
+	// downbeatWeight multiplies notes on the first beat of a bar
+	downbeatWeight = 1.5
+	// finalWeight multiplies the notes of the last step, which usually rest on the tonic
+	finalWeight = 2
+)
+
+// NoteWeights sums how long each pitch class sounds, in beats, counting
+// downbeats and the final notes more
+func (l Lesson) NoteWeights() [12]float64 {
+	var weights [12]float64
+	for i, step := range l.Steps {
+		factor := 1.0
+		if (step.Beat-1)%barBeats == 0 {
+			factor *= downbeatWeight
+		}
+		if i == len(l.Steps)-1 {
+			factor *= finalWeight
+		}
+		for _, m := range step.Markers {
+			if m.Fret < 0 {
+				continue
+			}
+			weights[m.Note] += float64(max(m.Duration, 1)) * factor
+		}
+	}
+	return weights
+}
+
+// DetectKey guesses the lesson's key and scale from its notes
+func (l Lesson) DetectKey() (theory.KeyGuess, bool) {
+	guesses := theory.DetectKey(l.NoteWeights())
+	if len(guesses) == 0 {
+		return theory.KeyGuess{}, false
+	}
+	return guesses[0], true
+}
+
+// detectMissingKey fills ActualKey from the notes when there is no KEY header
+func (l *Lesson) detectMissingKey() {
+	if l.KeyStr != "" {
+		return
+	}
+	if guess, ok := l.DetectKey(); ok {
+		l.DetectedKey = &guess
+		l.ActualKey = guess.Root
+	}
+}
@@ -0,0 +1,49 @@
+package lesson
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestDetectMissingKey(t *testing.T) {
+	// The A minor box lesson with its KEY header taken out; the B on the
+	// high e makes it natural minor rather than pentatonic
+	data, err := os.ReadFile(filepath.Join("..", "..", "lessons_tab", "01_a_minor_pentatonic_box1.tab"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "KEY:") {
+			lines = append(lines, line)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "nokey.tab")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := LoadTabFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.DetectedKey == nil {
+		t.Fatal("no key detected")
+	}
+	if g := *l.DetectedKey; g.Root != theory.A || g.Scale != "minor" || l.ActualKey != theory.A {
+		t.Errorf("detected %s", g)
+	}
+	if got := l.Key().String(); got != "Am" {
+		t.Errorf("lesson key %s, want Am", got)
+	}
+
+	// A written key is kept
+	l, _ = LoadTabFile(filepath.Join("..", "..", "lessons_tab", "test_picking.tab"))
+	if l.DetectedKey != nil || l.ActualKey != theory.C {
+		t.Errorf("KEY: C replaced by %v", l.DetectedKey)
+	}
+}
@@ -0,0 +1,232 @@
+package lesson
+
+import (
+	"fmt"
+	"math/rand"
+
+	"guitui/internal/theory"
+)
+
+// Lick generator settings
+const (
+	// LickBPM is the beat of generated licks; steps are eighth notes
+	LickBPM = 80
+	// lickSteps is the number of steps per beat (eighths)
+	lickSteps = 2
+	// DefaultLickBars is the length of the call; the response is as long
+	DefaultLickBars = 2
+	// DefaultTechniqueChance is how often a bend, slide or legato is used where one fits
+	DefaultTechniqueChance = 0.35
+)
+
+// LickConfig chooses where and how a lick is generated
+type LickConfig struct {
+	Scale      string // theory.Scales key
+	Root       theory.Note
+	Position   theory.Position
+	Seed       int64   // The same seed gives the same lick
+	Bars       int     // Length of the call, 0 = DefaultLickBars
+	Techniques float64 // Chance (0-1) of a technique where one fits
+}
+
+// lickRhythms are the rhythms a beat can take, in eighths (0 = rest),
+// repeated to weight the choice
+var lickRhythms = [][]int{
+	{1, 1}, {1, 1}, {1, 1},
+	{2}, {2},
+	{0, 1},
+	{1, 0},
+}
+
+// lickEvent is one note of a lick while it is written
+type lickEvent struct {
+	step     int // Eighth from the start, 0-based
+	length   int // Eighths
+	note     int // Index into the position notes
+	marker   Marker
+	absorbed bool // Played as the target of the previous note's technique
+}
+
+// GenerateLick writes a random phrase inside a scale position, then leaves
+// as many bars empty for the player to answer (call and response). Beats
+// take one of a few rhythms; notes move mostly stepwise through the
+// position, land on chord tones on strong beats and end long on the root.
+// Same-string pairs may become hammer-ons, pull-offs or slides, long notes
+// may be bent up to a scale note and the last note gets vibrato.
+func GenerateLick(c LickConfig) Lesson {
+	rng := rand.New(rand.NewSource(c.Seed))
+	bars := c.Bars
+	if bars <= 0 {
+		bars = DefaultLickBars
+	}
+	key := theory.ScaleKey(c.Root, c.Scale)
+	callSteps := bars * barBeats * lickSteps
+
+	l := Lesson{
+		Title: fmt.Sprintf("Lick #%d: %s %s %d", c.Seed, theory.ScaleDisplayName(c.Scale),
+			positionTypeName(c.Position.Type), c.Position.Index),
+		Category:     "lick",
+		BPM:          LickBPM * lickSteps,
+		KeyStr:       key.String(),
+		ActualKey:    c.Root,
+		ResponseBeat: callSteps + 1,
+	}
+
+	notes := theory.PositionNotes(c.Position, c.Root, theory.StandardTuning)
+	if len(notes) == 0 {
+		return l
+	}
+	pitches := make([]theory.Pitch, len(notes))
+	for i, n := range notes {
+		pitches[i] = theory.StandardTuningPitches[n.String].Transpose(n.Fret)
+	}
+	chordTone := func(i int) bool {
+		interval := (int(pitches[i].Note()) - int(c.Root) + 12) % 12
+		third := 4
+		if key.Minor {
+			third = 3
+		}
+		return interval == 0 || interval == third || interval == 7
+	}
+
+	// Rhythm: the last two beats hold the final note
+	var events []lickEvent
+	for beat := 0; beat < bars*barBeats-2; beat++ {
+		step := beat * lickSteps
+		for _, length := range lickRhythms[rng.Intn(len(lickRhythms))] {
+			if length > 0 {
+				events = append(events, lickEvent{step: step, length: length})
+			}
+			step += max(length, 1)
+		}
+	}
+	events = append(events, lickEvent{step: callSteps - 2*lickSteps, length: 2 * lickSteps})
+
+	// Melody: a walk through the position that keeps its direction for a
+	// while, snapping to chord tones on beats and to the root at the end
+	current := len(notes)/3 + rng.Intn(max(len(notes)/3, 1))
+	direction := 1
+	for i := range events {
+		if i > 0 {
+			if rng.Float64() < 0.3 {
+				direction = -direction
+			}
+			move := []int{1, 1, 1, 2, 2, 3}[rng.Intn(6)]
+			current += direction * move
+			if current < 0 || current >= len(notes) {
+				direction = -direction
+				current += 2 * direction * move
+			}
+			current = max(0, min(len(notes)-1, current))
+		}
+		switch {
+		case i == len(events)-1:
+			current = nearest(current, len(notes), func(k int) bool {
+				return pitches[k].Note() == c.Root
+			})
+		case events[i].step%lickSteps == 0 && i > 0:
+			// A chord tone other than the last note, so beats do not repeat it
+			prev := events[i-1].note
+			current = nearest(current, len(notes), func(k int) bool { return k != prev && chordTone(k) })
+		case events[i].step%lickSteps == 0:
+			current = nearest(current, len(notes), chordTone)
+		}
+		events[i].note = current
+	}
+
+	// Markers, alternate picking by the eighth: down on the beat, up off it
+	for i := range events {
+		n := notes[events[i].note]
+		events[i].marker = Marker{StringIndex: n.String, Fret: n.Fret, Finger: n.Finger, Duration: events[i].length}
+		events[i].marker.Picking = PickDown
+		if events[i].step%lickSteps != 0 {
+			events[i].marker.Picking = PickUp
+		}
+	}
+	addLickTechniques(events, pitches, c, rng)
+
+	for _, e := range events {
+		if e.absorbed {
+			continue
+		}
+		l.Steps = append(l.Steps, Step{
+			Beat:    e.step + 1,
+			Markers: []Marker{e.marker},
+			Accent:  e.step%(barBeats*lickSteps) == 0,
+		})
+	}
+	// The response: empty bars for the player's answer
+	l.Steps = append(l.Steps, Step{Beat: 2 * callSteps})
+	l.RecalculateNotes()
+	return l
+}
+
+// addLickTechniques turns an eighth-note pair on one string into a hammer-on,
+// pull-off or slide, bends long notes up to a scale note with a strong
+// enough finger and puts vibrato on the last note
+func addLickTechniques(events []lickEvent, pitches []theory.Pitch, c LickConfig, rng *rand.Rand) {
+	for i := 0; i+1 < len(events); i++ {
+		a, b := &events[i], &events[i+1]
+		if a.absorbed || a.length != 1 || b.step != a.step+1 || b.length != 1 || a.step%lickSteps != 0 {
+			continue
+		}
+		if a.marker.StringIndex != b.marker.StringIndex || a.marker.Fret == b.marker.Fret || a.marker.Fret == 0 {
+			continue
+		}
+		if rng.Float64() >= c.Techniques {
+			continue
+		}
+		switch {
+		case rng.Intn(3) == 0:
+			a.marker.Technique = TechSlide
+			a.marker.TechParams.SlideType = "up"
+			if b.marker.Fret < a.marker.Fret {
+				a.marker.TechParams.SlideType = "down"
+			}
+		case b.marker.Fret > a.marker.Fret:
+			a.marker.Technique = TechHammer
+		default:
+			a.marker.Technique = TechPullOff
+		}
+		a.marker.TechParams.TargetFret = b.marker.Fret
+		a.marker.Duration = 2
+		b.absorbed = true
+	}
+
+	for i := range events {
+		e := &events[i]
+		m := &e.marker
+		if e.absorbed || m.Technique != TechNone || e.length < 2 || i == len(events)-1 {
+			continue
+		}
+		if m.StringIndex < 2 || m.Finger < 3 || rng.Float64() >= c.Techniques {
+			continue
+		}
+		note := pitches[e.note].Note()
+		for _, semitones := range []int{2, 1} {
+			if theory.IsNoteInScale(theory.Note((int(note)+semitones)%12), c.Root, c.Scale) {
+				m.Technique = TechBend
+				m.TechParams.BendSteps = FormatBendSteps(float64(semitones))
+				break
+			}
+		}
+	}
+
+	if last := &events[len(events)-1]; last.marker.Technique == TechNone && c.Techniques > 0 {
+		last.marker.Technique = TechVibrato
+		last.marker.TechParams.VibratoWidth = "normal"
+	}
+}
+
+// nearest returns the index closest to i in 0..n-1 that passes ok, or i
+func nearest(i, n int, ok func(int) bool) int {
+	for d := 0; d < n; d++ {
+		if i-d >= 0 && ok(i-d) {
+			return i - d
+		}
+		if i+d < n && ok(i+d) {
+			return i + d
+		}
+	}
+	return i
+}
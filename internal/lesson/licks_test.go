@@ -0,0 +1,92 @@
+package lesson
+
+import (
+	"reflect"
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func lickConfig(t *testing.T, seed int64, techniques float64) LickConfig {
+	t.Helper()
+	pos, ok := theory.GetPosition("minor_pentatonic", theory.PositionTypeCAGED, 1)
+	if !ok {
+		t.Fatal("no minor pentatonic box 1")
+	}
+	return LickConfig{Scale: "minor_pentatonic", Root: theory.A, Position: pos, Seed: seed, Techniques: techniques}
+}
+
+func TestGenerateLick(t *testing.T) {
+	callSteps := DefaultLickBars * barBeats * lickSteps
+	chordTones := map[theory.Note]bool{theory.A: true, theory.C: true, theory.E: true}
+
+	for seed := int64(1); seed <= 50; seed++ {
+		l := GenerateLick(lickConfig(t, seed, DefaultTechniqueChance))
+		if l.ResponseBeat != callSteps+1 || l.KeyStr != "Am" {
+			t.Fatalf("seed %d: response on %d in %s", seed, l.ResponseBeat, l.KeyStr)
+		}
+		// The call, then one empty step that closes the response bars
+		end := l.Steps[len(l.Steps)-1]
+		if end.Beat != 2*callSteps || len(end.Markers) != 0 {
+			t.Errorf("seed %d: ends with %+v", seed, end)
+		}
+		played := l.Steps[:len(l.Steps)-1]
+		for _, step := range played {
+			m := step.Markers[0]
+			if step.Beat >= l.ResponseBeat {
+				t.Errorf("seed %d: note on beat %d in the response", seed, step.Beat)
+			}
+			if !theory.IsNoteInScale(m.Note, theory.A, "minor_pentatonic") {
+				t.Errorf("seed %d beat %d: %s outside the scale", seed, step.Beat, theory.NoteNames[m.Note])
+			}
+			if step.Beat%lickSteps == 1 && !chordTones[m.Note] {
+				t.Errorf("seed %d beat %d: %s on the beat is not a chord tone", seed, step.Beat, theory.NoteNames[m.Note])
+			}
+			if m.Technique == TechBend && !theory.IsNoteInScale(m.BendTargetPitch().Note(), theory.A, "minor_pentatonic") {
+				t.Errorf("seed %d beat %d: bend to %s", seed, step.Beat, m.BendTargetPitch())
+			}
+		}
+		if last := played[len(played)-1].Markers[0]; last.Note != theory.A || last.Duration != 2*lickSteps {
+			t.Errorf("seed %d: ends on %s for %d steps", seed, theory.NoteNames[last.Note], last.Duration)
+		}
+	}
+}
+
+func TestGenerateLickSeeds(t *testing.T) {
+	a := GenerateLick(lickConfig(t, 7, DefaultTechniqueChance))
+	if b := GenerateLick(lickConfig(t, 7, DefaultTechniqueChance)); !reflect.DeepEqual(a, b) {
+		t.Error("the same seed gave two licks")
+	}
+	if c := GenerateLick(lickConfig(t, 8, DefaultTechniqueChance)); reflect.DeepEqual(a.Steps, c.Steps) {
+		t.Error("seeds 7 and 8 gave the same lick")
+	}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		for _, step := range GenerateLick(lickConfig(t, seed, 0)).Steps {
+			for _, m := range step.Markers {
+				if m.Technique != TechNone {
+					t.Errorf("seed %d: %s with techniques off", seed, m.Technique)
+				}
+			}
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	even := func(k int) bool { return k%2 == 0 }
+	tests := []struct {
+		i, n int
+		ok   func(int) bool
+		want int
+	}{
+		{4, 10, even, 4},
+		{5, 10, even, 4}, // Ties go down
+		{9, 10, func(k int) bool { return k == 0 }, 0},
+		{3, 10, func(int) bool { return false }, 3},
+	}
+	for _, tt := range tests {
+		if got := nearest(tt.i, tt.n, tt.ok); got != tt.want {
+			t.Errorf("nearest(%d, %d) = %d, want %d", tt.i, tt.n, got, tt.want)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strings"
 
 	"guitui/internal/theory"
 )
@@ -25,28 +24,19 @@ func LoadLessons(path string) ([]Lesson, error) {
 	for i := range lessons {
 		l := &lessons[i]
 		l.ActualKey = parseNote(l.KeyStr)
-		
+
 		// Calculate note for each marker based on string + fret
-		for j := range l.Steps {
-			for k := range l.Steps[j].Markers {
-				marker := &l.Steps[j].Markers[k]
-				if marker.StringIndex >= 0 && marker.StringIndex < 6 {
-					openNote := theory.StandardTuning[marker.StringIndex]
-					marker.Note = theory.CalculateNote(openNote, marker.Fret)
-				}
-			}
-		}
+		l.RecalculateNotes()
+		l.detectMissingKey()
 	}
 
 	return lessons, nil
 }
 
+// parseNote reads the note name at the start of a key header ("A", "F#m", "Bb")
 func parseNote(n string) theory.Note {
-	n = strings.TrimSpace(n)
-	for i, name := range theory.NoteNames {
-		if strings.EqualFold(name, n) {
-			return theory.Note(i)
-		}
+	if key, ok := theory.ParseKey(n); ok {
+		return key.Root()
 	}
 	return theory.C
 }
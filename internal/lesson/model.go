@@ -39,8 +39,12 @@ type TechniqueParams struct {
 	TargetFret   int    // For slides, hammer-ons, pull-offs
 	BendSteps    string // Bend amount: "1", "½", "1½", "2" etc.
 	BendRelease  bool   // True if bend has release (r suffix)
+	BendSemitones float64   // Highest bend offset in semitones: ½ step = 1, ¼ step = 0.5
+	BendCurve     []float64 // Offsets (semitones) the bend moves through: 7b9r7 = [0 2 0]
 	VibratoWidth string // "normal", "wide" for vibrato
 	SlideType    string // "up", "down", "in", "out" for slides
+	HarmonicNode float64 // Touch point (fret position from the nut) for harmonics: 7, 3.2, 17
+	HarmonicType string  // "natural" or "artificial" for harmonics
 }
 
 // Marker: Một điểm trên cần đàn
@@ -49,6 +53,7 @@ type Marker struct {
 	Fret        int         `json:"fret"`
 	Finger      int         `json:"finger"` // 0: Open, 1-4: Ngón tay
 	Note        theory.Note `json:"-"`      // Calculated at runtime
+	Pitch       theory.Pitch `json:"-"`     // Sounding pitch with octave, calculated at runtime
 	Beat        int         `json:"-"`      // Optional manual beat number (used during parsing)
 	Duration    int         `json:"duration,omitempty"` // Number of beats to hold (default 1)
 	
@@ -72,14 +77,65 @@ type Step struct {
 
 // Lesson: Cấu trúc bài học tổng thể (load từ JSON)
 type Lesson struct {
-	Title    string `json:"title"`
-	Category string `json:"category"`
-	BPM      int    `json:"bpm"`
-	KeyStr   string `json:"key"`
+	Title      string `json:"title"`
+	Category   string `json:"category"`
+	BPM        int    `json:"bpm"`
+	KeyStr     string `json:"key"`
+	Difficulty string `json:"difficulty,omitempty"` // Hand-written level (DIFFICULTY: header)
+	Capo       Capo   `json:"capo,omitempty"`       // Tab frets are relative to the capo
 	
 	// Steps được define thủ công trong JSON
 	Steps []Step `json:"steps"`
 	
 	// Runtime data
-	ActualKey theory.Note `json:"-"`
+	ActualKey   theory.Note      `json:"-"`
+	DetectedKey *theory.KeyGuess `json:"-"` // Guessed from the notes when there is no KEY header
+	SourcePath  string           `json:"-"` // .tab file the lesson was loaded from
+	Sections    []TabSection     `json:"-"` // Every SECTION block of the .tab file; Steps hold the first
+	ResponseBeat int             `json:"-"` // Call-and-response licks: first beat of the player's answer, 0 = none
+}
+
+// Clone returns a deep copy of the lesson so its steps can be edited
+// without touching the original
+func (l Lesson) Clone() Lesson {
+	c := l
+	c.Steps = make([]Step, len(l.Steps))
+	for i, step := range l.Steps {
+		c.Steps[i] = step
+		c.Steps[i].Markers = append([]Marker(nil), step.Markers...)
+	}
+	return c
+}
+
+// Key returns the lesson's key with its spelling (Bb, not A#)
+func (l Lesson) Key() theory.Key {
+	if key, ok := theory.ParseKey(l.KeyStr); ok {
+		return key
+	}
+	if l.DetectedKey != nil {
+		return l.DetectedKey.Key()
+	}
+	return theory.DefaultKey(l.ActualKey, false)
+}
+
+// RecalculateNotes recomputes every marker's sounding note and pitch from
+// its string and fret, taking the capo into account
+func (l *Lesson) RecalculateNotes() {
+	for i := range l.Steps {
+		for k := range l.Steps[i].Markers {
+			m := &l.Steps[i].Markers[k]
+			if m.Fret < 0 || m.StringIndex < 0 || m.StringIndex >= len(theory.StandardTuning) {
+				continue
+			}
+			m.fillBend()
+			fret := l.Capo.AbsoluteFret(m.StringIndex, m.Fret)
+			m.Pitch = theory.CalculatePitch(theory.StandardTuningPitches[m.StringIndex], fret)
+			if stopped, semitones, ok := harmonicShift(*m); ok {
+				// Harmonics sound above the stopped (or open) string, not at the node
+				stopped = l.Capo.AbsoluteFret(m.StringIndex, stopped)
+				m.Pitch = theory.CalculatePitch(theory.StandardTuningPitches[m.StringIndex], stopped+semitones)
+			}
+			m.Note = m.Pitch.Note()
+		}
+	}
 }
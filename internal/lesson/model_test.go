@@ -0,0 +1,30 @@
+package lesson
+
+import (
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestRecalculateNotesPitch(t *testing.T) {
+	tests := []struct {
+		name   string
+		capo   Capo
+		marker Marker
+		want   theory.Pitch
+	}{
+		{"open low E", Capo{}, Marker{StringIndex: 0, Fret: 0}, theory.NewPitch(theory.E, 2)},
+		{"high e fret 12", Capo{}, Marker{StringIndex: 5, Fret: 12}, theory.NewPitch(theory.E, 5)},
+		{"G string over B", Capo{}, Marker{StringIndex: 3, Fret: 5}, theory.NewPitch(theory.C, 4)},
+		{"tab 0 under a capo at 2", Capo{Fret: 2}, Marker{StringIndex: 1, Fret: 0}, theory.NewPitch(theory.B, 2)},
+		{"string the partial capo skips", Capo{Fret: 2, Strings: []int{1, 2, 3, 4}}, Marker{StringIndex: 5, Fret: 0}, theory.NewPitch(theory.E, 4)},
+	}
+	for _, tt := range tests {
+		l := Lesson{Capo: tt.capo, Steps: []Step{{Beat: 1, Markers: []Marker{tt.marker}}}}
+		l.RecalculateNotes()
+		m := l.Steps[0].Markers[0]
+		if m.Pitch != tt.want || m.Note != tt.want.Note() {
+			t.Errorf("%s: %s (%s), want %s", tt.name, m.Pitch, theory.NoteNames[m.Note], tt.want)
+		}
+	}
+}
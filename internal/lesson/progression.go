@@ -0,0 +1,64 @@
+package lesson
+
+import (
+	"guitui/internal/theory"
+)
+
+// ChordEvent is a chord heard from a beat until the next event
+type ChordEvent struct {
+	Beat  int
+	Chord theory.ChordMatch // Best match
+}
+
+// ChordProgression names the chords of the lesson: the notes sounding at
+// each step, held notes included, with the lowest pitch as bass. Single
+// notes and unnamed clusters are skipped and repeats of the same chord
+// are merged.
+func (l Lesson) ChordProgression() []ChordEvent {
+	var events []ChordEvent
+	for _, step := range l.Steps {
+		var notes []theory.Note
+		var bass Marker
+		for _, s := range l.Steps {
+			if s.Beat > step.Beat {
+				continue
+			}
+			for _, m := range s.Markers {
+				if m.Fret < 0 || step.Beat >= s.Beat+max(m.Duration, 1) {
+					continue
+				}
+				if len(notes) == 0 || m.Pitch < bass.Pitch {
+					bass = m
+				}
+				notes = append(notes, m.Note)
+			}
+		}
+		if len(notes) < 2 {
+			continue
+		}
+		matches := theory.IdentifyChord(notes, bass.Note)
+		if len(matches) == 0 {
+			continue
+		}
+		chord := matches[0]
+		if n := len(events); n > 0 && sameChord(events[n-1].Chord, chord) {
+			continue
+		}
+		events = append(events, ChordEvent{Beat: step.Beat, Chord: chord})
+	}
+	return events
+}
+
+// Chords returns the chords of a progression in order
+func Chords(events []ChordEvent) []theory.ChordMatch {
+	chords := make([]theory.ChordMatch, len(events))
+	for i, e := range events {
+		chords[i] = e.Chord
+	}
+	return chords
+}
+
+// sameChord compares root, bass and quality
+func sameChord(a, b theory.ChordMatch) bool {
+	return a.Root == b.Root && a.Bass == b.Bass && a.Quality.Suffix == b.Quality.Suffix
+}
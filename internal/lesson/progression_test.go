@@ -0,0 +1,42 @@
+package lesson
+
+import (
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestChordProgression(t *testing.T) {
+	// Am arpeggio over a held open A, then C/E and a repeated C/E
+	note := func(s, fret, duration int) Marker {
+		return Marker{StringIndex: s, Fret: fret, Duration: duration}
+	}
+	l := Lesson{Steps: []Step{
+		{Beat: 1, Markers: []Marker{note(1, 0, 3)}},
+		{Beat: 2, Markers: []Marker{note(2, 2, 2)}},
+		{Beat: 3, Markers: []Marker{note(3, 2, 1), note(4, 1, 1)}},
+		{Beat: 4, Markers: []Marker{note(0, 0, 1), note(3, 0, 1), note(4, 1, 1)}},
+		{Beat: 5, Markers: []Marker{note(0, 0, 1), note(3, 0, 1), note(4, 1, 1)}},
+		{Beat: 6, Markers: []Marker{note(5, 0, 1)}}, // A single note: no chord
+	}}
+	l.RecalculateNotes()
+
+	c := theory.DefaultKey(theory.C, false)
+	want := []struct {
+		beat int
+		name string
+	}{
+		{2, "A5"}, // A and E so far
+		{3, "Am"},
+		{4, "C/E"},
+	}
+	events := l.ChordProgression()
+	if len(events) != len(want) {
+		t.Fatalf("%d chords, want %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if e.Beat != want[i].beat || e.Chord.Name(c) != want[i].name {
+			t.Errorf("chord %d: %s on beat %d, want %s on beat %d", i+1, e.Chord.Name(c), e.Beat, want[i].name, want[i].beat)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package lesson
+
+import (
+	"fmt"
+
+	"guitui/internal/theory"
+)
+
+// SequenceBPM is the beat of generated sequences; the notes run at a
+// multiple of it
+const SequenceBPM = 60
+
+// SequenceLesson plays a scale position as a melodic sequence, one note per
+// step and subdivision steps per beat: the tempo is multiplied and the
+// first note of every beat is accented. Notes alternate down and up
+// strokes and keep the position's fingering.
+func SequenceLesson(scale string, root theory.Note, pos theory.Position, p theory.SequencePattern, descending bool, subdivision int) Lesson {
+	subdivision = max(subdivision, 1)
+	direction := "ascending"
+	if descending {
+		direction = "descending"
+	}
+	l := Lesson{
+		Title: fmt.Sprintf("%s %s %d: %s %s (%s)", theory.ScaleDisplayName(scale), positionTypeName(pos.Type),
+			pos.Index, p.Name, direction, phrasingName(subdivision)),
+		Category:  "sequence",
+		BPM:       SequenceBPM * subdivision,
+		KeyStr:    theory.ScaleKey(root, scale).String(),
+		ActualKey: root,
+	}
+
+	notes := p.Apply(theory.PositionNotes(pos, root, theory.StandardTuning), descending)
+	for i, n := range notes {
+		pick := PickDown
+		if i%2 == 1 {
+			pick = PickUp
+		}
+		l.Steps = append(l.Steps, Step{
+			Beat:    i + 1,
+			Markers: []Marker{{StringIndex: n.String, Fret: n.Fret, Finger: n.Finger, Picking: pick}},
+			Accent:  i%subdivision == 0,
+		})
+	}
+	l.RecalculateNotes()
+	return l
+}
+
+// SequenceLessons generates every pattern over a position, each ascending
+// then descending, in the pattern's natural phrasing
+func SequenceLessons(scale string, root theory.Note, pos theory.Position) []Lesson {
+	var lessons []Lesson
+	for _, p := range theory.SequencePatterns {
+		for _, descending := range []bool{false, true} {
+			lessons = append(lessons, SequenceLesson(scale, root, pos, p, descending, p.Subdivision()))
+		}
+	}
+	return lessons
+}
+
+// positionTypeName is the title name of a position system
+func positionTypeName(t theory.PositionType) string {
+	if t == theory.PositionType3NPS {
+		return "3NPS"
+	}
+	return "CAGED"
+}
+
+// phrasingName names the notes per beat
+func phrasingName(subdivision int) string {
+	switch subdivision {
+	case 1:
+		return "quarters"
+	case 2:
+		return "eighths"
+	case 3:
+		return "triplets"
+	case 4:
+		return "sixteenths"
+	case 6:
+		return "sextuplets"
+	}
+	return fmt.Sprintf("%d per beat", subdivision)
+}
@@ -0,0 +1,42 @@
+package lesson
+
+import (
+	"strings"
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestSequenceLessons(t *testing.T) {
+	pos, _ := theory.GetPosition("minor_pentatonic", theory.PositionTypeCAGED, 1)
+	lessons := SequenceLessons("minor_pentatonic", theory.A, pos)
+	if len(lessons) != 2*len(theory.SequencePatterns) {
+		t.Fatalf("%d lessons, want each pattern up and down", len(lessons))
+	}
+
+	up := lessons[0]
+	if want := "Minor Pentatonic CAGED 1: Groups of 3 ascending (triplets)"; up.Title != want {
+		t.Errorf("title %q, want %q", up.Title, want)
+	}
+	if up.BPM != 3*SequenceBPM || up.KeyStr != "Am" {
+		t.Errorf("%d BPM in %s, want %d in Am", up.BPM, up.KeyStr, 3*SequenceBPM)
+	}
+	// 12 notes in groups of 3: 10 groups
+	if len(up.Steps) != 30 {
+		t.Errorf("%d steps, want 30", len(up.Steps))
+	}
+	for i, step := range up.Steps {
+		m := step.Markers[0]
+		if step.Accent != (i%3 == 0) {
+			t.Errorf("beat %d: accent %v", step.Beat, step.Accent)
+		}
+		if want := []PickingType{PickDown, PickUp}[i%2]; m.Picking != want {
+			t.Errorf("beat %d: %s stroke, want %s", step.Beat, m.Picking, want)
+		}
+	}
+
+	down := lessons[1]
+	if !strings.Contains(down.Title, "descending") || down.Steps[0].Markers[0].StringIndex != 5 {
+		t.Errorf("%s starts on string %d", down.Title, 6-down.Steps[0].Markers[0].StringIndex)
+	}
+}
@@ -15,6 +15,12 @@ import (
 type TabParser struct {
 	metadata map[string]string
 	tabLines map[int]string // stringIndex (0-5) -> tab line
+
+	lineNumbers map[int]int // stringIndex -> line number (0-based) of its tab line
+	columnBeats []int       // beat number produced by each cell column (0 = none)
+
+	inTabSection      bool
+	foundFirstSection bool
 }
 
 // String names to index mapping
@@ -27,6 +33,14 @@ var stringNames = map[string]int{
 	"E": 0, // Low E (string 6)
 }
 
+func newTabParser() *TabParser {
+	return &TabParser{
+		metadata:    make(map[string]string),
+		tabLines:    make(map[int]string),
+		lineNumbers: make(map[int]int),
+	}
+}
+
 // LoadTabFile loads and parses a .tab file
 func LoadTabFile(path string) (*Lesson, error) {
 	file, err := os.Open(path)
@@ -35,60 +49,111 @@ func LoadTabFile(path string) (*Lesson, error) {
 	}
 	defer file.Close()
 
-	parser := &TabParser{
-		metadata: make(map[string]string),
-		tabLines: make(map[int]string),
-	}
+	parser := newTabParser()
 
+	// Only the first section is played, but every line is kept so the
+	// other SECTION blocks can be analyzed
+	var lines []string
+	parsing := true
 	scanner := bufio.NewScanner(file)
-	inTabSection := false
-	foundFirstSection := false
+	for lineNo := 0; scanner.Scan(); lineNo++ {
+		lines = append(lines, scanner.Text())
+		if parsing {
+			parsing = parser.scanLine(scanner.Text(), lineNo)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	// Build lesson from parsed data
+	lesson, err := parser.buildLesson()
+	if err != nil {
+		return nil, err
+	}
+	lesson.SourcePath = path
+	lesson.Sections = parseTabSections(lines, lesson.Capo)
+	return lesson, nil
+}
+
+// TabSection is one SECTION block of a tab file, its beats counted from 1
+type TabSection struct {
+	Name  string // The header line: "SECTION 2: SLIDE (8 beats)"
+	Steps []Step
+}
 
-	for scanner.Scan() {
-		line := scanner.Text()
+// parseTabSections parses every SECTION block of a tab file on its own, up
+// to the NOTES or LEGEND part. Nil for a file without SECTION headers.
+func parseTabSections(lines []string, capo Capo) []TabSection {
+	var sections []TabSection
+	var block *TabParser
+	var name string
 
-		// Skip SECTION headers
+	flush := func() {
+		if block == nil || len(block.tabLines) == 0 {
+			return
+		}
+		l := Lesson{Capo: capo, Steps: block.parseSteps()}
+		l.RecalculateNotes()
+		sections = append(sections, TabSection{Name: name, Steps: l.Steps})
+	}
+
+	for lineNo, line := range lines {
+		if strings.HasPrefix(line, "NOTES:") || strings.HasPrefix(line, "LEGEND:") {
+			break
+		}
 		if strings.HasPrefix(line, "SECTION") {
-			if foundFirstSection {
-				// Stop at second SECTION - only parse first section
-				break
-			}
-			foundFirstSection = true
+			flush()
+			block = newTabParser()
+			name = strings.TrimSpace(line)
 			continue
 		}
-
-		// Parse metadata
-		if strings.Contains(line, ":") && !inTabSection {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				key := strings.TrimSpace(parts[0])
-				value := strings.TrimSpace(parts[1])
-				parser.metadata[key] = value
-			}
+		if block != nil && strings.Contains(line, "|") {
+			block.parseTabLine(line, lineNo)
 		}
+	}
+	flush()
+	return sections
+}
 
-		// Parse tab lines
-		if strings.Contains(line, "|") {
-			inTabSection = true
-			parser.parseTabLine(line)
+// scanLine feeds one file line to the parser. Returns false when parsing should stop.
+func (p *TabParser) scanLine(line string, lineNo int) bool {
+	// Skip SECTION headers
+	if strings.HasPrefix(line, "SECTION") {
+		if p.foundFirstSection {
+			// Stop at second SECTION - only parse first section
+			return false
 		}
+		p.foundFirstSection = true
+		return true
+	}
 
-		// Stop at NOTES section
-		if strings.HasPrefix(line, "NOTES:") || strings.HasPrefix(line, "LEGEND:") {
-			break
+	// Parse metadata
+	if strings.Contains(line, ":") && !p.inTabSection {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 {
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+			p.metadata[key] = value
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+	// Parse tab lines
+	if strings.Contains(line, "|") {
+		p.inTabSection = true
+		p.parseTabLine(line, lineNo)
 	}
 
-	// Build lesson from parsed data
-	return parser.buildLesson()
+	// Stop at NOTES section
+	if strings.HasPrefix(line, "NOTES:") || strings.HasPrefix(line, "LEGEND:") {
+		return false
+	}
+	return true
 }
 
 // parseTabLine extracts tab notation from a line
-func (p *TabParser) parseTabLine(line string) {
+func (p *TabParser) parseTabLine(line string, lineNo int) {
 	// Format: "e|-----5f1-----7f3-----|"
 	// Extract string name and content
 	parts := strings.SplitN(line, "|", 2)
@@ -105,16 +170,18 @@ func (p *TabParser) parseTabLine(line string) {
 	if idx, ok := stringNames[stringName]; ok {
 		// Only single section now - just set directly
 		p.tabLines[idx] = content
+		p.lineNumbers[idx] = lineNo
 	}
 }
 
 // buildLesson converts parsed tab to Lesson structure
 func (p *TabParser) buildLesson() (*Lesson, error) {
 	lesson := &Lesson{
-		Title:    p.metadata["TITLE"],
-		Category: strings.ToLower(p.metadata["CATEGORY"]),
-		KeyStr:   p.metadata["KEY"],
-		Steps:    []Step{},
+		Title:      p.metadata["TITLE"],
+		Category:   strings.ToLower(p.metadata["CATEGORY"]),
+		KeyStr:     p.metadata["KEY"],
+		Difficulty: strings.ToLower(p.metadata["DIFFICULTY"]),
+		Steps:      []Step{},
 	}
 
 	// Parse BPM
@@ -124,6 +191,13 @@ func (p *TabParser) buildLesson() (*Lesson, error) {
 		}
 	}
 
+	// Parse capo (tab frets are relative to it)
+	capo, err := ParseCapo(p.metadata["CAPO"])
+	if err != nil {
+		return nil, err
+	}
+	lesson.Capo = capo
+
 	// Parse actual key note
 	lesson.ActualKey = parseNote(lesson.KeyStr)
 
@@ -131,6 +205,10 @@ func (p *TabParser) buildLesson() (*Lesson, error) {
 	steps := p.parseSteps()
 	lesson.Steps = steps
 
+	// Sounding notes depend on the capo
+	lesson.RecalculateNotes()
+	lesson.detectMissingKey()
+
 	return lesson, nil
 }
 
@@ -152,21 +230,9 @@ func (p *TabParser) parseSteps() []Step {
 
 		// Split by | delimiter - each cell between | is one beat
 		cells := strings.Split(line, "|")
-		
-		// Keep ALL cells between pipes, including empty ones (they are rest beats)
-		// Only remove the very first and very last if they're from string start/end
 		var filteredCells []string
-		for i, cell := range cells {
-			// Skip first cell if it's from before the first |
-			if i == 0 && strings.TrimSpace(cell) == "" {
-				continue
-			}
-			// Skip last cell if it's from after the final |
-			if i == len(cells)-1 && strings.TrimSpace(cell) == "" {
-				continue
-			}
-			// Keep everything else, including empty cells (rest beats)
-			filteredCells = append(filteredCells, cell)
+		for _, i := range beatCellIndexes(cells) {
+			filteredCells = append(filteredCells, cells[i])
 		}
 
 		beatCells[stringIdx] = filteredCells
@@ -182,6 +248,8 @@ func (p *TabParser) parseSteps() []Step {
 	// Track last played note on each string for hold extension
 	lastNoteOnString := make(map[int]*Marker) // stringIdx -> last marker
 
+	p.columnBeats = make([]int, maxBeats)
+
 	for beatIdx := 0; beatIdx < maxBeats; beatIdx++ {
 		// Check if this is a skip beat (all cells empty)
 		allEmpty := true
@@ -239,6 +307,7 @@ func (p *TabParser) parseSteps() []Step {
 				Markers: []Marker{},
 			}
 			steps = append(steps, step)
+			p.columnBeats[beatIdx] = beatNumber
 			beatNumber++
 		} else if len(beatMarkers) > 0 {
 			// Create ONE step with all markers in this beat
@@ -254,9 +323,11 @@ func (p *TabParser) parseSteps() []Step {
 				lastNoteOnString[step.Markers[i].StringIndex] = &step.Markers[i]
 			}
 			
+			p.columnBeats[beatIdx] = beatNumber
 			beatNumber++
 		} else if hasHold {
 			// Only holds in this beat - don't create new step, just increment beat
+			p.columnBeats[beatIdx] = beatNumber
 			beatNumber++
 		}
 	}
@@ -264,8 +335,27 @@ func (p *TabParser) parseSteps() []Step {
 	return steps
 }
 
+// beatCellIndexes returns the indexes of the cells that are beats.
+// Keep ALL cells between pipes, including empty ones (they are rest beats).
+// Only remove the very first and very last if they're from string start/end.
+func beatCellIndexes(cells []string) []int {
+	var indexes []int
+	for i, cell := range cells {
+		// Skip first cell if it's from before the first |
+		if i == 0 && strings.TrimSpace(cell) == "" {
+			continue
+		}
+		// Skip last cell if it's from after the final |
+		if i == len(cells)-1 && strings.TrimSpace(cell) == "" {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
 // parseCell parses a single beat cell for one string
-// Cell format examples: "5(f1)", "7b9", "5/7", "5h7", "12t", "<12>", "x"
+// Cell format examples: "5(f1)", "7b9", "5/7", "5h7", "12t", "<12>", "5AH17", "x"
 func (p *TabParser) parseCell(stringIdx int, cell string) *Marker {
 	cell = strings.TrimSpace(cell)
 	
@@ -284,26 +374,13 @@ func (p *TabParser) parseCell(stringIdx int, cell string) *Marker {
 		}
 	}
 
-	// Check for harmonic notation: <12>
-	if strings.HasPrefix(cell, "<") && strings.Contains(cell, ">") {
-		fretStr := strings.TrimPrefix(cell, "<")
-		fretStr = strings.TrimSuffix(fretStr, ">")
-		fret, _ := strconv.Atoi(fretStr)
-		
-		openNote := theory.StandardTuning[stringIdx]
-		note := theory.CalculateNote(openNote, fret)
-		
-		return &Marker{
-			StringIndex: stringIdx,
-			Fret:        fret,
-			Finger:      0,
-			Note:        note,
-			Technique:   TechHarmonic,
-		}
+	// Check for harmonic notation: <12>, <3.2>, NH12, AH17, 5AH17
+	if m := p.parseHarmonicCell(stringIdx, cell); m != nil {
+		return m
 	}
 
-	// Check if starts with a digit (fret number)
-	if len(cell) > 0 && cell[0] >= '0' && cell[0] <= '9' {
+	// Check if starts with a digit (fret number) or a pre-bend
+	if (len(cell) > 0 && cell[0] >= '0' && cell[0] <= '9') || strings.HasPrefix(cell, "pb{") {
 		// Extract fret, finger, and technique
 		fret, finger := p.extractFretFinger(cell)
 		technique, params := p.extractTechnique(cell)
@@ -334,8 +411,14 @@ func (p *TabParser) extractFretFinger(cell string) (fret, finger int) {
 	finger = 0
 
 	// Parse fret number (can be multi-digit: 10, 12, etc.)
+	// A pre-bend's fret follows its amount: pb{1}7
 	fretStr := ""
 	i := 0
+	if strings.HasPrefix(cell, "pb{") {
+		if end := strings.Index(cell, "}"); end != -1 {
+			i = end + 1
+		}
+	}
 	for i < len(cell) && cell[i] >= '0' && cell[i] <= '9' {
 		fretStr += string(cell[i])
 		i++
@@ -345,8 +428,9 @@ func (p *TabParser) extractFretFinger(cell string) (fret, finger int) {
 		fret, _ = strconv.Atoi(fretStr)
 	}
 
-	// Check for finger notation in parentheses
-	if i < len(cell) && cell[i] == '(' {
+	// Check for finger notation in parentheses. It may follow a technique: "5h7(f1)"
+	if open := strings.Index(cell[i:], "("); open != -1 {
+		i += open
 		// Find closing parenthesis
 		closeIdx := strings.Index(cell[i:], ")")
 		if closeIdx != -1 {
@@ -381,7 +465,7 @@ func (p *TabParser) extractFretFinger(cell string) (fret, finger int) {
 }
 
 // extractTechnique parses technique notation from cell string
-// Supports: 7b{1} (bend), pb{1}7 (pre-bend), 5/7 (slide up), 7\5 (slide down), 5h7 (hammer), 7p5 (pull), 5~ (vibrato), 12t (tap), 5l7 (trill)
+// Supports: 7b{1} / 7b9 / 7b9r7b9 (bend), pb{1}7 (pre-bend), 5/7 (slide up), 7\5 (slide down), 5h7 (hammer), 7p5 (pull), 5~ (vibrato), 12t (tap), 5l7 (trill)
 func (p *TabParser) extractTechnique(cell string) (TechniqueType, TechniqueParams) {
 	params := TechniqueParams{}
 	
@@ -391,40 +475,9 @@ func (p *TabParser) extractTechnique(cell string) (TechniqueType, TechniqueParam
 		cellClean = cell[:idx]
 	}
 	
-	// Check for pre-bend: pb{1}7 or pb{½}7r
-	if strings.HasPrefix(cellClean, "pb{") {
-		// Format: pb{1}7 or pb{½}7r
-		endBrace := strings.Index(cellClean, "}")
-		if endBrace != -1 {
-			bendSteps := cellClean[3:endBrace] // Extract "1", "½", "1½" etc.
-			params.BendSteps = bendSteps
-			
-			// Check for release
-			if strings.Contains(cellClean, "r") {
-				params.BendRelease = true
-			}
-			
-			return TechPreBend, params
-		}
-	}
-	
-	// Check for bend: 7b{1} or 7b{½}r
-	if strings.Contains(cellClean, "b{") {
-		// Format: 7b{1} or 7b{½}r or 7b{1½}
-		startBrace := strings.Index(cellClean, "{")
-		endBrace := strings.Index(cellClean, "}")
-		
-		if startBrace != -1 && endBrace != -1 {
-			bendSteps := cellClean[startBrace+1 : endBrace] // Extract "1", "½", "1½" etc.
-			params.BendSteps = bendSteps
-			
-			// Check for release (r after })
-			if strings.Contains(cellClean[endBrace:], "r") {
-				params.BendRelease = true
-			}
-			
-			return TechBend, params
-		}
+	// Check for bends: 7b{1}, 7b9r7, 7b1/4, pb{1}7r
+	if technique, bendParams, ok := parseBend(cellClean); ok {
+		return technique, bendParams
 	}
 	
 	// Check for slide up: 5/7
@@ -638,7 +691,7 @@ func detectTechnique(line string, pos int) string {
 		"vibrato":   regexp.MustCompile(`\d+~+`),
 		"trill":     regexp.MustCompile(`\d+l\d+`),
 		"tap":       regexp.MustCompile(`\d+t`),
-		"harmonic":  regexp.MustCompile(`<\d+>`),
+		"harmonic":  regexp.MustCompile(`<\d+(\.\d+)?>|[NA]H\d+`),
 		"pinch":     regexp.MustCompile(`\d+\*`),
 	}
 
@@ -0,0 +1,282 @@
+package lesson
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"guitui/internal/theory"
+)
+
+// NeckFrets is the highest fret a transposed note may land on
+const NeckFrets = 24
+
+// Transpose returns a copy of the lesson shifted by semitones, keeping the
+// shape. When the shifted shape falls off the neck it moves to the nearest
+// playable position an octave away; if it fits nowhere, notes are re-fretted
+// one by one to the same pitch on another string. Key, notes and technique
+// target frets follow.
+func (l Lesson) Transpose(semitones int) Lesson {
+	t := l.Clone()
+	if semitones == 0 {
+		return t
+	}
+
+	// Tab frets are capo-relative, so a capo shortens the usable neck
+	limit := NeckFrets - t.Capo.Fret
+
+	delta, fits := semitones, shapeFits(t, semitones, limit)
+	for _, alt := range octaveAlternatives(semitones) {
+		if fits {
+			break
+		}
+		delta, fits = alt, shapeFits(t, alt, limit)
+	}
+
+	offsets := openStringOffsets(theory.StandardTuning)
+	if fits {
+		for i := range t.Steps {
+			for k := range t.Steps[i].Markers {
+				if !shiftMarker(&t.Steps[i].Markers[k], delta) {
+					lowerHarmonic(&t.Steps[i], k, delta, limit, offsets)
+				}
+			}
+		}
+	} else {
+		center := min(max(shapeCenter(t)+semitones, 0), limit)
+		for i := range t.Steps {
+			refretStep(&t.Steps[i], semitones, center, limit, offsets)
+		}
+	}
+
+	t.ActualKey = theory.Note(((int(l.ActualKey)+semitones)%12 + 12) % 12)
+	if name, rest := splitKeyStr(l.KeyStr); name != "" {
+		t.KeyStr = theory.DefaultKey(t.ActualKey, l.Key().Minor).Tonic.String() + rest
+	}
+	if l.DetectedKey != nil {
+		guess := *l.DetectedKey
+		guess.Root = t.ActualKey
+		t.DetectedKey = &guess
+	}
+	t.RecalculateNotes()
+	return t
+}
+
+// octaveAlternatives lists the shifts with the same pitch class as semitones,
+// nearest first: for -6 that is +6, -18, +18
+func octaveAlternatives(semitones int) []int {
+	var alts []int
+	for octave := 12; octave <= 24; octave += 12 {
+		up, down := semitones+octave, semitones-octave
+		if absInt(up) <= absInt(down) {
+			alts = append(alts, up, down)
+		} else {
+			alts = append(alts, down, up)
+		}
+	}
+	return alts
+}
+
+// openStringOffsets returns each open string's distance in semitones from the lowest string
+func openStringOffsets(tuning []theory.Note) []int {
+	offsets := make([]int, len(tuning))
+	for s := 1; s < len(tuning); s++ {
+		interval := (int(tuning[s]) - int(tuning[s-1]) + 12) % 12
+		offsets[s] = offsets[s-1] + interval
+	}
+	return offsets
+}
+
+// markerFrets returns the frets a marker needs: its own and its technique target
+func markerFrets(m Marker) []int {
+	frets := []int{m.Fret}
+	if m.TechParams.TargetFret > 0 {
+		switch m.Technique {
+		case TechSlide, TechHammer, TechPullOff, TechTrill:
+			frets = append(frets, m.TechParams.TargetFret)
+		}
+	}
+	return frets
+}
+
+func onNeck(fret, limit int) bool {
+	return fret >= 0 && fret <= limit
+}
+
+// shapeFits reports whether every note stays within frets 0..limit when shifted by delta frets
+func shapeFits(l Lesson, delta, limit int) bool {
+	for _, step := range l.Steps {
+		for _, m := range step.Markers {
+			if m.Fret < 0 {
+				continue
+			}
+			for _, f := range markerFrets(m) {
+				if !onNeck(f+delta, limit) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// shapeCenter is the median fret of the lesson's fretted notes
+func shapeCenter(l Lesson) int {
+	var frets []int
+	for _, step := range l.Steps {
+		for _, m := range step.Markers {
+			if m.Fret > 0 {
+				frets = append(frets, m.Fret)
+			}
+		}
+	}
+	if len(frets) == 0 {
+		return 0
+	}
+	sort.Ints(frets)
+	return frets[len(frets)/2]
+}
+
+// shiftMarker moves a marker (and its technique target) by delta frets on
+// the same string. It fails, leaving the marker as it was, for a natural
+// harmonic moved down (see lowerHarmonic).
+func shiftMarker(m *Marker, delta int) bool {
+	if m.Fret < 0 {
+		return true
+	}
+	if m.Technique == TechHarmonic {
+		return shiftHarmonic(m, delta)
+	}
+	m.Fret += delta
+	if m.TechParams.TargetFret > 0 {
+		m.TechParams.TargetFret += delta
+	}
+	if m.Fret == 0 {
+		m.Finger = 0 // Open strings take no finger
+	}
+	return true
+}
+
+// shiftHarmonic moves a harmonic's stopped fret and node together, so it
+// keeps its partial. A natural harmonic moved up becomes an artificial one;
+// one moved down fails, as the string can't be stopped below the nut.
+func shiftHarmonic(m *Marker, delta int) bool {
+	if delta == 0 {
+		return true
+	}
+	node := m.harmonicNode()
+	if m.TechParams.HarmonicType != HarmonicArtificial {
+		if delta < 0 {
+			return false
+		}
+		m.TechParams.HarmonicType = HarmonicArtificial
+		m.Fret = 0
+	}
+	m.Fret += delta
+	m.TechParams.HarmonicNode = node + float64(delta)
+	return true
+}
+
+// lowerHarmonic moves natural harmonic k of a step down by delta semitones:
+// the same harmonic on the nearest lower string the step leaves free,
+// stopped when the string is not delta below it. With no such string it
+// becomes an artificial harmonic on its own string, an octave higher.
+func lowerHarmonic(step *Step, k, delta, limit int, offsets []int) {
+	m := &step.Markers[k]
+	used := make(map[int]bool)
+	for i, other := range step.Markers {
+		if i != k {
+			used[other.StringIndex] = true
+		}
+	}
+
+	pitch := offsets[m.StringIndex] + delta
+	for s := m.StringIndex - 1; s >= 0; s-- {
+		fret := pitch - offsets[s]
+		if used[s] || fret < 0 {
+			continue
+		}
+		if fret > limit {
+			break
+		}
+		m.StringIndex = s
+		shiftHarmonic(m, fret)
+		return
+	}
+	shiftHarmonic(m, (delta%12+12)%12)
+}
+
+// refretStep transposes a step note by note, choosing for each note the string
+// that keeps its pitch on the neck closest to center. Strings already used by
+// another note of the step are avoided.
+func refretStep(step *Step, semitones, center, limit int, offsets []int) {
+	used := make(map[int]bool)
+
+	for k := range step.Markers {
+		m := &step.Markers[k]
+		if m.Fret < 0 {
+			used[m.StringIndex] = true
+			continue
+		}
+
+		pitch := offsets[m.StringIndex] + m.Fret + semitones
+		bestString, bestDist := -1, math.MaxInt
+		for s := range offsets {
+			if used[s] {
+				continue
+			}
+			fret := pitch - offsets[s]
+			moved := *m
+			moved.StringIndex = s
+			if !shiftMarker(&moved, fret-m.Fret) || !allOnNeck(moved, limit) {
+				continue
+			}
+			if d := absInt(fret - center); d < bestDist {
+				bestString, bestDist = s, d
+			}
+		}
+
+		if bestString < 0 {
+			// No string can hold this pitch: keep the string, move by an octave
+			delta := semitones
+			for m.Fret+delta < 0 {
+				delta += 12
+			}
+			for m.Fret+delta > limit {
+				delta -= 12
+			}
+			if !shiftMarker(m, delta) {
+				lowerHarmonic(step, k, delta, limit, offsets)
+			}
+			used[m.StringIndex] = true
+			continue
+		}
+
+		fret := pitch - offsets[bestString]
+		shiftMarker(m, fret-m.Fret)
+		m.StringIndex = bestString
+		used[bestString] = true
+	}
+}
+
+func allOnNeck(m Marker, limit int) bool {
+	for _, f := range markerFrets(m) {
+		if !onNeck(f, limit) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitKeyStr splits a key header into note name and the rest: "F#m" -> "F#", "m"
+func splitKeyStr(key string) (name, rest string) {
+	key = strings.TrimSpace(key)
+	if key == "" || !strings.ContainsRune("ABCDEFGabcdefg", rune(key[0])) {
+		return "", key
+	}
+	n := 1
+	for n < len(key) && (key[n] == '#' || key[n] == 'b') {
+		n++
+	}
+	return key[:n], key[n:]
+}
@@ -0,0 +1,115 @@
+package lesson
+
+import (
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+// markerAt is a marker and its step's beat, for reporting
+type markerAt struct {
+	beat int
+	m    Marker
+}
+
+func allMarkers(l Lesson) []markerAt {
+	var all []markerAt
+	for _, step := range l.Steps {
+		for _, m := range step.Markers {
+			all = append(all, markerAt{step.Beat, m})
+		}
+	}
+	return all
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		notes     [][2]int // {string, fret}, one step each
+		semitones int
+		wantKey   string
+		wantFrets []int
+	}{
+		{"shape up", "Am", [][2]int{{0, 5}, {0, 8}, {1, 5}}, 2, "Bm", []int{7, 10, 7}},
+		{"flat key", "F", [][2]int{{0, 1}, {1, 3}}, 5, "Bb", []int{6, 8}},
+		{"off the top: an octave down", "C", [][2]int{{0, 22}, {1, 24}}, 3, "Eb", []int{13, 15}},
+		{"below the nut: an octave up", "E", [][2]int{{0, 0}, {0, 2}}, -1, "Eb", []int{11, 13}},
+	}
+	for _, tt := range tests {
+		l := frets(tt.notes...)
+		l.KeyStr = tt.key
+		l.ActualKey = l.Key().Root()
+		got := l.Transpose(tt.semitones)
+		if got.KeyStr != tt.wantKey {
+			t.Errorf("%s: key %s, want %s", tt.name, got.KeyStr, tt.wantKey)
+		}
+		for i, m := range allMarkers(got) {
+			if m.m.Fret != tt.wantFrets[i] || m.m.StringIndex != tt.notes[i][0] {
+				t.Errorf("%s beat %d: string %d fret %d, want string %d fret %d",
+					tt.name, m.beat, m.m.StringIndex, m.m.Fret, tt.notes[i][0], tt.wantFrets[i])
+			}
+		}
+	}
+}
+
+func TestTransposeRefrets(t *testing.T) {
+	// No shift of the whole shape keeps fret 1 of the low E and fret 24 of
+	// the high e on the neck: the notes move one by one
+	l := frets([2]int{0, 1}, [2]int{5, 24})
+	l.RecalculateNotes()
+	got := l.Transpose(-2)
+	before, after := allMarkers(l), allMarkers(got)
+
+	// Fret -1 of the low E doesn't exist: the octave above
+	if m := after[0].m; m.StringIndex != 0 || m.Fret != 11 {
+		t.Errorf("low F moved to string %d fret %d, want string 6 fret 11", 6-m.StringIndex, m.Fret)
+	}
+	// The high E becomes the D right below it, not an octave away
+	if a, b := after[1].m, before[1].m; a.Pitch != b.Pitch-2 || a.Fret > NeckFrets {
+		t.Errorf("high E moved to string %d fret %d (%s), want %s", 6-a.StringIndex, a.Fret, a.Pitch, b.Pitch-2)
+	}
+	for i := range after {
+		if want := theory.Note((int(before[i].m.Note) + 10) % 12); after[i].m.Note != want {
+			t.Errorf("beat %d: %s, want %s", after[i].beat, theory.NoteNames[after[i].m.Note], theory.NoteNames[want])
+		}
+	}
+}
+
+func TestTransposeHarmonics(t *testing.T) {
+	tests := []struct {
+		name      string
+		semitones int
+		fret      int
+		node      float64
+	}{
+		{"up: stopped at the shift", 2, 2, 14},
+		{"down on the low string: an octave up", -2, 10, 22},
+	}
+	for _, tt := range tests {
+		l := Lesson{Steps: []Step{{Beat: 1, Markers: []Marker{{
+			StringIndex: 0, Fret: 12, Technique: TechHarmonic,
+			TechParams: TechniqueParams{HarmonicNode: 12, HarmonicType: HarmonicNatural},
+		}}}}}
+		m := l.Transpose(tt.semitones).Steps[0].Markers[0]
+		if m.TechParams.HarmonicType != HarmonicArtificial || m.Fret != tt.fret || m.TechParams.HarmonicNode != tt.node {
+			t.Errorf("%s: %s harmonic at fret %d node %g, want artificial at %d node %g",
+				tt.name, m.TechParams.HarmonicType, m.Fret, m.TechParams.HarmonicNode, tt.fret, tt.node)
+		}
+	}
+}
+
+func TestSplitKeyStr(t *testing.T) {
+	tests := []struct{ key, name, rest string }{
+		{"F#m", "F#", "m"},
+		{"Bb", "Bb", ""},
+		{" A minor", "A", " minor"},
+		{"", "", ""},
+		{"none", "", "none"},
+	}
+	for _, tt := range tests {
+		if name, rest := splitKeyStr(tt.key); name != tt.name || rest != tt.rest {
+			t.Errorf("splitKeyStr(%q) = %q, %q", tt.key, name, rest)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package theory
+
+import (
+	"strings"
+)
+
+// Kinds of chord function
+const (
+	FunctionDiatonic  = "diatonic"
+	FunctionSecondary = "secondary dominant"
+	FunctionBorrowed  = "borrowed"
+	FunctionChromatic = "chromatic"
+)
+
+// degreeNames gives the scale degree of each interval above the tonic,
+// flats against the major scale: 3 = bIII, 10 = bVII
+var degreeNames = [12]struct {
+	accidental string
+	degree     int // 0-6
+}{
+	{"", 0}, {"b", 1}, {"", 1}, {"b", 2}, {"", 2}, {"", 3},
+	{"b", 4}, {"", 4}, {"b", 5}, {"", 5}, {"b", 6}, {"", 6},
+}
+
+// ChordFunction is the analysis of one chord in a key
+type ChordFunction struct {
+	Chord     ChordMatch
+	Numeral   string // Roman numeral: "I", "V7", "ii65", "V7/ii", "bVII"
+	Nashville string // Nashville number: "1", "57", "2-7", "b7", "1/3"
+	Kind      string // FunctionDiatonic, FunctionSecondary...
+	Pattern   string // Set on the chord that completes "ii–V", "V–I" or "IV–I"
+}
+
+// AnalyzeChord labels a chord by its function in key k
+func AnalyzeChord(c ChordMatch, k Key) ChordFunction {
+	f := ChordFunction{Chord: c, Kind: FunctionChromatic}
+	tones := chordNotes(c)
+
+	switch {
+	case fitsScale(tones, k.Root(), keyScale(k)):
+		f.Kind = FunctionDiatonic
+	case isDominant(c) && secondaryTarget(c, k) >= 0:
+		f.Kind = FunctionSecondary
+	case fitsScale(tones, k.Root(), keyScale(Key{Tonic: k.Tonic, Minor: !k.Minor})):
+		f.Kind = FunctionBorrowed
+	}
+
+	f.Numeral = romanNumeral(c, k.Root())
+	if f.Kind == FunctionSecondary {
+		target := secondaryTarget(c, k)
+		f.Numeral = romanNumeral(ChordMatch{Root: c.Root, Bass: c.Root, Quality: c.Quality}, target) +
+			"/" + diatonicNumeral(target, k)
+	}
+	f.Nashville = nashvilleNumber(c, k.Root())
+	return f
+}
+
+// AnalyzeProgression labels each chord and marks the ii–V, V–I and IV–I moves
+func AnalyzeProgression(chords []ChordMatch, k Key) []ChordFunction {
+	functions := make([]ChordFunction, len(chords))
+	for i, c := range chords {
+		functions[i] = AnalyzeChord(c, k)
+	}
+	// Dominants: the key's V, secondary dominants and any dominant 7th
+	dominant := func(i int) bool {
+		c := chords[i]
+		if !isDominant(c) {
+			return false
+		}
+		return functions[i].Kind == FunctionSecondary || containsInterval(c.Quality.Intervals, 10) ||
+			c.Root == Note((int(k.Root())+7)%12)
+	}
+	for i := 1; i < len(chords); i++ {
+		prev, cur := chords[i-1], chords[i]
+		fallsFifth := (int(prev.Root)-int(cur.Root)+12)%12 == 7
+		switch {
+		case fallsFifth && dominant(i) && prev.Quality.Minor():
+			functions[i].Pattern = "ii–V"
+		case fallsFifth && dominant(i-1):
+			functions[i].Pattern = "V–I"
+		case (int(prev.Root)-int(cur.Root)+12)%12 == 5 && cur.Root == k.Root() && !prev.Quality.Minor():
+			functions[i].Pattern = "IV–I"
+		}
+	}
+	return functions
+}
+
+// romanNumeral writes the chord as a numeral above tonic: case for quality,
+// ° and ø for diminished, extensions and figured-bass inversions
+func romanNumeral(c ChordMatch, tonic Note) string {
+	d := degreeNames[(int(c.Root)-int(tonic)+12)%12]
+	numeral := romanNumerals[d.degree]
+
+	suffix := c.Quality.Suffix
+	switch {
+	case suffix == "dim":
+		numeral, suffix = strings.ToLower(numeral)+"°", ""
+	case suffix == "dim7":
+		numeral, suffix = strings.ToLower(numeral)+"°", "7"
+	case suffix == "m7b5":
+		numeral, suffix = strings.ToLower(numeral)+"ø", "7"
+	case suffix == "aug":
+		numeral, suffix = numeral+"+", ""
+	case c.Quality.Minor():
+		numeral = strings.ToLower(numeral)
+		suffix = strings.TrimPrefix(suffix, "m")
+	}
+
+	// Figured bass for inversions of triads and 7ths: I6, I64, V65, V43, V42
+	if inv := c.Inversion(); inv > 0 && tertian(c.Quality) {
+		seventh := strings.HasSuffix(suffix, "7")
+		figures := map[bool][]string{false: {"", "6", "64"}, true: {"", "65", "43", "42"}}[seventh]
+		if inv < len(figures) {
+			suffix = strings.TrimSuffix(suffix, "7") + figures[inv]
+		}
+	}
+	return d.accidental + numeral + suffix
+}
+
+// nashvilleNumber writes the chord in Nashville notation: the root's degree,
+// "-" for minor, "°" and "ø" for diminished, the bass degree after a slash
+func nashvilleNumber(c ChordMatch, tonic Note) string {
+	d := degreeNames[(int(c.Root)-int(tonic)+12)%12]
+	number := d.accidental + string(rune('1'+d.degree))
+
+	suffix := c.Quality.Suffix
+	switch {
+	case suffix == "5":
+		suffix = "(5)" // Not "15", which reads as a number
+	case suffix == "m7b5":
+		suffix = "ø"
+	case strings.HasPrefix(suffix, "dim"):
+		suffix = "°" + strings.TrimPrefix(suffix, "dim")
+	case c.Quality.Minor():
+		suffix = "-" + strings.TrimPrefix(suffix, "m")
+	}
+	number += suffix
+
+	if c.Bass != c.Root {
+		b := degreeNames[(int(c.Bass)-int(tonic)+12)%12]
+		number += "/" + b.accidental + string(rune('1'+b.degree))
+	}
+	return number
+}
+
+// diatonicNumeral is the numeral of the key's chord on root: "ii", "IV"
+func diatonicNumeral(root Note, k Key) string {
+	for _, c := range DiatonicChords(k) {
+		if c.Root.Note() == root {
+			return c.Numeral
+		}
+	}
+	return romanNumeral(ChordMatch{Root: root, Bass: root, Quality: ChordQualities[0]}, k.Root())
+}
+
+// secondaryTarget returns the diatonic chord root a dominant chord resolves
+// to (a fifth below), or -1 when it is the key's own V or the target is
+// diminished
+func secondaryTarget(c ChordMatch, k Key) Note {
+	target := Note((int(c.Root) + 5) % 12)
+	if target == k.Root() {
+		return -1
+	}
+	for _, d := range DiatonicChords(k) {
+		if d.Root.Note() == target && d.Triad.Suffix != "dim" {
+			return target
+		}
+	}
+	return -1
+}
+
+// tertian reports whether the chord is a stack of thirds with figured-bass
+// inversions: triads and 7ths, not power, sus or add chords
+func tertian(q ChordQuality) bool {
+	switch q.Suffix {
+	case "", "m", "dim", "aug", "7", "maj7", "m7", "m7b5", "dim7", "mMaj7":
+		return true
+	}
+	return false
+}
+
+// isDominant reports whether the chord can act as a dominant: a major
+// triad, or a major 3rd with a minor 7th (7, 9, 13, 7b9...)
+func isDominant(c ChordMatch) bool {
+	q := c.Quality.Intervals
+	if !containsInterval(q, 4) || containsInterval(q, 11) {
+		return false
+	}
+	return containsInterval(q, 10) || c.Quality.Suffix == ""
+}
+
+// keyScale is the key's diatonic scale; minor keys add the raised 7th of
+// harmonic minor so V and V7 count as diatonic
+func keyScale(k Key) ScaleFormula {
+	if k.Minor {
+		return append(append(ScaleFormula{}, Scales["minor"]...), 11)
+	}
+	return Scales["major"]
+}
+
+// chordNotes lists the notes the chord sounds
+func chordNotes(c ChordMatch) []Note {
+	var notes []Note
+	for _, interval := range c.Quality.Intervals {
+		if !containsInterval(c.Omitted, interval) {
+			notes = append(notes, Note((int(c.Root)+interval)%12))
+		}
+	}
+	return notes
+}
+
+// fitsScale reports whether every note is in the scale on root
+func fitsScale(notes []Note, root Note, scale ScaleFormula) bool {
+	for _, n := range notes {
+		if !containsInterval(scale, (int(n)-int(root)+12)%12) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package theory
+
+import "testing"
+
+// chordOf builds a chord match: root, quality suffix and bass
+func chordOf(t *testing.T, root Note, suffix string, bass Note) ChordMatch {
+	t.Helper()
+	return ChordMatch{Root: root, Bass: bass, Quality: qualityBySuffix(t, suffix)}
+}
+
+func TestAnalyzeChord(t *testing.T) {
+	tests := []struct {
+		key       string
+		root      Note
+		suffix    string
+		bass      Note
+		numeral   string
+		nashville string
+		kind      string
+	}{
+		{"C", C, "", C, "I", "1", FunctionDiatonic},
+		{"C", D, "m7", D, "ii7", "2-7", FunctionDiatonic},
+		{"C", G, "7", G, "V7", "57", FunctionDiatonic},
+		{"C", C, "", E, "I6", "1/3", FunctionDiatonic},
+		{"C", C, "", G, "I64", "1/5", FunctionDiatonic},
+		{"C", G, "7", B, "V65", "57/7", FunctionDiatonic},
+		{"C", G, "7", F, "V42", "57/4", FunctionDiatonic},
+		{"C", B, "m7b5", B, "viiø7", "7ø", FunctionDiatonic},
+		{"C", B, "dim", B, "vii°", "7°", FunctionDiatonic},
+		{"C", E, "5", E, "III5", "3(5)", FunctionDiatonic},
+		{"C", A, "7", A, "V7/ii", "67", FunctionSecondary},
+		{"C", D, "", D, "V/V", "2", FunctionSecondary},
+		{"C", As, "", As, "bVII", "b7", FunctionBorrowed},
+		{"C", F, "m", F, "iv", "4-", FunctionBorrowed},
+		{"C", Cs, "", Cs, "bII", "b2", FunctionChromatic},
+		{"Am", E, "7", E, "V7", "57", FunctionDiatonic}, // Raised 7th of harmonic minor
+		{"Am", G, "", G, "bVII", "b7", FunctionDiatonic},
+		{"Am", C, "", C, "bIII", "b3", FunctionDiatonic},
+	}
+	for _, tt := range tests {
+		k, _ := ParseKey(tt.key)
+		c := chordOf(t, tt.root, tt.suffix, tt.bass)
+		f := AnalyzeChord(c, k)
+		if f.Numeral != tt.numeral || f.Nashville != tt.nashville || f.Kind != tt.kind {
+			t.Errorf("%s in %s = %s / %s (%s), want %s / %s (%s)", c.Name(k), tt.key,
+				f.Numeral, f.Nashville, f.Kind, tt.numeral, tt.nashville, tt.kind)
+		}
+	}
+}
+
+func TestAnalyzeProgression(t *testing.T) {
+	c := DefaultKey(C, false)
+	tests := []struct {
+		name     string
+		chords   []ChordMatch
+		patterns []string
+	}{
+		{"ii–V–I", []ChordMatch{chordOf(t, D, "m7", D), chordOf(t, G, "7", G), chordOf(t, C, "maj7", C)},
+			[]string{"", "ii–V", "V–I"}},
+		{"plagal", []ChordMatch{chordOf(t, F, "", F), chordOf(t, C, "", C)}, []string{"", "IV–I"}},
+		{"secondary dominant", []ChordMatch{chordOf(t, A, "7", A), chordOf(t, D, "m", D)}, []string{"", "V–I"}},
+		{"down a fifth, no dominant", []ChordMatch{chordOf(t, A, "m", A), chordOf(t, D, "m", D)}, []string{"", ""}},
+	}
+	for _, tt := range tests {
+		for i, f := range AnalyzeProgression(tt.chords, c) {
+			if f.Pattern != tt.patterns[i] {
+				t.Errorf("%s chord %d: pattern %q, want %q", tt.name, i+1, f.Pattern, tt.patterns[i])
+			}
+		}
+	}
+}
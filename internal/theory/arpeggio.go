@@ -0,0 +1,244 @@
+package theory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ArpeggioShape is a way of laying a chord out as single notes
+type ArpeggioShape string
+
+const (
+	ArpeggioSweep      ArpeggioShape = "sweep"       // One note per string, two on the top string
+	ArpeggioStringSkip ArpeggioShape = "string-skip" // Up to two notes on every other string
+	ArpeggioBox        ArpeggioShape = "box"         // One octave, root to root
+)
+
+// ArpeggioShapes lists the shapes in menu order
+var ArpeggioShapes = []ArpeggioShape{ArpeggioSweep, ArpeggioStringSkip, ArpeggioBox}
+
+// stringSkipSets are the strings of string-skipping shapes (0 = low E)
+var stringSkipSets = [][]int{{0, 2, 4}, {1, 3, 5}}
+
+// ArpeggioNote is one note of an arpeggio shape
+type ArpeggioNote struct {
+	String   int // 0 = low E
+	Fret     int
+	Finger   int // 0 = open, 1-4
+	Interval int // Semitones above the chord root (0-11)
+}
+
+// Arpeggio is a chord played one note at a time, low to high, in one hand
+// position
+type Arpeggio struct {
+	Root    Note
+	Quality ChordQuality
+	Shape   ArpeggioShape
+	Notes   []ArpeggioNote
+}
+
+// Position is the lowest fret of the shape
+func (a Arpeggio) Position() int {
+	lowest := a.Notes[0].Fret
+	for _, n := range a.Notes {
+		lowest = min(lowest, n.Fret)
+	}
+	return lowest
+}
+
+// Chord is the chord the arpeggio spells, its first note as bass
+func (a Arpeggio) Chord() ChordMatch {
+	return ChordMatch{Root: a.Root, Bass: Note((int(a.Root) + a.Notes[0].Interval) % 12), Quality: a.Quality}
+}
+
+// fretSpan counts the frets the fretted notes cover
+func (a Arpeggio) fretSpan() int {
+	lo, hi := 0, 0
+	for _, n := range a.Notes {
+		if n.Fret > 0 {
+			if lo == 0 || n.Fret < lo {
+				lo = n.Fret
+			}
+			hi = max(hi, n.Fret)
+		}
+	}
+	if lo == 0 {
+		return 0
+	}
+	return hi - lo + 1
+}
+
+// String writes the frets per string, low to high: "5 3 2 2 1 0-5"
+func (a Arpeggio) String() string {
+	var parts []string
+	for i, n := range a.Notes {
+		fret := fmt.Sprint(n.Fret)
+		if i > 0 && a.Notes[i-1].String == n.String {
+			parts[len(parts)-1] += "-" + fret
+			continue
+		}
+		parts = append(parts, fret)
+	}
+	return strings.Join(parts, " ")
+}
+
+// GenerateArpeggios lays the chord out in one shape across the neck. Sweep
+// and string-skip shapes start on every chord tone, so the inversions are
+// included; boxes start on the root of each of the lower strings. Each
+// shape fits the hand in four frets and they come sorted by position.
+func GenerateArpeggios(root Note, quality ChordQuality, shape ArpeggioShape, tuning []Note, fretCount int) []Arpeggio {
+	opens := TuningPitches(tuning)
+
+	var sets [][]int
+	tones := quality.Intervals
+	switch shape {
+	case ArpeggioSweep:
+		sets = [][]int{allStrings(len(tuning))}
+	case ArpeggioStringSkip:
+		for _, set := range stringSkipSets {
+			if set[len(set)-1] < len(tuning) {
+				sets = append(sets, set)
+			}
+		}
+	case ArpeggioBox:
+		tones = []int{0}
+		all := allStrings(len(tuning))
+		for s := 0; s+3 <= len(tuning); s++ {
+			sets = append(sets, all[s:])
+		}
+	}
+
+	var arpeggios []Arpeggio
+	seen := make(map[string]bool)
+	for _, set := range sets {
+		for _, tone := range tones {
+			note := Note((int(root) + tone) % 12)
+			first := (int(note) - int(opens[set[0]].Note()) + 12) % 12
+			for fret := first; fret+handSpan-1 <= fretCount; fret += 12 {
+				a, ok := placeArpeggio(opens, root, quality, shape, set, fret)
+				id := fmt.Sprint(set[0], ":", a)
+				if !ok || seen[id] {
+					continue
+				}
+				seen[id] = true
+				arpeggios = append(arpeggios, a)
+			}
+		}
+	}
+
+	sort.SliceStable(arpeggios, func(i, j int) bool {
+		return arpeggios[i].Position() < arpeggios[j].Position()
+	})
+	return arpeggios
+}
+
+// placeArpeggio walks up the strings of set from fret start on the first
+// one, taking the chord tones that fit the hand above the previous note.
+// It fails when a string has no chord tone, a box misses the octave or a
+// chord tone is left out (only the 5th of 5+ note chords may be).
+func placeArpeggio(opens []Pitch, root Note, quality ChordQuality, shape ArpeggioShape, set []int, start int) (Arpeggio, bool) {
+	a := Arpeggio{Root: root, Quality: quality, Shape: shape}
+	lo, hi := max(start-1, 0), start+handSpan-1
+	first := opens[set[0]].Transpose(start)
+	prev := first - 1
+
+	for i, s := range set {
+		limit := 2
+		if shape == ArpeggioSweep && i < len(set)-1 {
+			limit = 1
+		}
+		if shape == ArpeggioBox {
+			limit = handSpan
+		}
+
+		var frets, intervals []int
+		for fret := lo; fret <= hi && len(frets) < limit; fret++ {
+			p := opens[s].Transpose(fret)
+			if p <= prev || shape == ArpeggioBox && p > first+12 {
+				continue
+			}
+			interval := (int(p.Note()) - int(root) + 12) % 12
+			if !containsInterval(quality.Intervals, interval) {
+				continue
+			}
+			frets = append(frets, fret)
+			intervals = append(intervals, interval)
+			prev = p
+		}
+		if len(frets) == 0 {
+			if shape == ArpeggioBox && prev == first+12 {
+				break
+			}
+			return Arpeggio{}, false
+		}
+
+		for k, fret := range frets {
+			a.Notes = append(a.Notes, ArpeggioNote{String: s, Fret: fret, Interval: intervals[k]})
+		}
+	}
+
+	if shape == ArpeggioBox && prev != first+12 {
+		return Arpeggio{}, false
+	}
+	if !a.spellsChord() {
+		return Arpeggio{}, false
+	}
+	// Reaching back and stretching both would need a fifth finger
+	if a.fretSpan() > handSpan {
+		return Arpeggio{}, false
+	}
+	fingerArpeggio(a.Notes)
+	return a, true
+}
+
+// spellsChord reports whether every chord tone is played; the 5th may be
+// left out of chords with five or more notes
+func (a Arpeggio) spellsChord() bool {
+	var played [12]bool
+	for _, n := range a.Notes {
+		played[n.Interval] = true
+	}
+	for _, interval := range a.Quality.Intervals {
+		if !played[interval%12] && (interval != 7 || len(a.Quality.Intervals) < 5) {
+			return false
+		}
+	}
+	return true
+}
+
+// fingerArpeggio puts the index on the lowest fretted note and gives one
+// finger per fret from there, string by string; open strings take no finger
+func fingerArpeggio(notes []ArpeggioNote) {
+	hand := 0
+	for _, n := range notes {
+		if n.Fret > 0 && (hand == 0 || n.Fret < hand) {
+			hand = n.Fret
+		}
+	}
+	for i := 0; i < len(notes); {
+		j := i
+		var frets []int
+		for ; j < len(notes) && notes[j].String == notes[i].String; j++ {
+			if notes[j].Fret > 0 {
+				frets = append(frets, notes[j].Fret)
+			}
+		}
+		fingers := assignStringFingers(frets, hand)
+		for k := i; k < j; k++ {
+			if notes[k].Fret > 0 {
+				notes[k].Finger, fingers = fingers[0], fingers[1:]
+			}
+		}
+		i = j
+	}
+}
+
+// allStrings lists string indexes 0..n-1
+func allStrings(n int) []int {
+	strs := make([]int, n)
+	for i := range strs {
+		strs[i] = i
+	}
+	return strs
+}
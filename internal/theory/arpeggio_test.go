@@ -0,0 +1,90 @@
+package theory
+
+import "testing"
+
+func TestGenerateArpeggios(t *testing.T) {
+	const fretCount = 15
+	for _, suffix := range []string{"", "m", "7", "m7b5", "maj9"} {
+		quality := qualityBySuffix(t, suffix)
+		for _, shape := range ArpeggioShapes {
+			arpeggios := GenerateArpeggios(A, quality, shape, StandardTuning, fretCount)
+			// Four and five note chords don't always fit a shape in 15 frets
+			if len(arpeggios) == 0 && len(quality.Intervals) == 3 {
+				t.Errorf("A%s %s: no shapes", suffix, shape)
+			}
+			for i, a := range arpeggios {
+				name := "A" + suffix + " " + string(shape) + " " + a.String()
+				if i > 0 && a.Position() < arpeggios[i-1].Position() {
+					t.Errorf("%s: out of position order", name)
+				}
+				if !a.spellsChord() || a.fretSpan() > handSpan {
+					t.Errorf("%s: misses a chord tone or spans %d frets", name, a.fretSpan())
+				}
+				checkArpeggioNotes(t, name, a)
+
+				switch shape {
+				case ArpeggioSweep:
+					if a.Notes[0].String != 0 || a.Notes[len(a.Notes)-1].String != 5 {
+						t.Errorf("%s: doesn't sweep every string", name)
+					}
+				case ArpeggioBox:
+					first, last := a.Notes[0], a.Notes[len(a.Notes)-1]
+					low := CalculatePitch(StandardTuningPitches[first.String], first.Fret)
+					high := CalculatePitch(StandardTuningPitches[last.String], last.Fret)
+					if first.Interval != 0 || high != low+12 {
+						t.Errorf("%s: not root to root", name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkArpeggioNotes checks that the notes climb, play the interval they
+// claim and are fingered one finger per fret
+func checkArpeggioNotes(t *testing.T, name string, a Arpeggio) {
+	t.Helper()
+	var prev Pitch
+	for i, n := range a.Notes {
+		p := CalculatePitch(StandardTuningPitches[n.String], n.Fret)
+		if i > 0 && p <= prev {
+			t.Errorf("%s: note %d doesn't climb", name, i+1)
+		}
+		prev = p
+		if interval := (int(p.Note()) - int(a.Root) + 12) % 12; interval != n.Interval {
+			t.Errorf("%s: note %d is interval %d, marked %d", name, i+1, interval, n.Interval)
+		}
+		if (n.Fret == 0) != (n.Finger == 0) || n.Finger > 4 {
+			t.Errorf("%s: fret %d with finger %d", name, n.Fret, n.Finger)
+		}
+	}
+}
+
+func TestArpeggioShapes(t *testing.T) {
+	tests := []struct {
+		suffix   string
+		shape    ArpeggioShape
+		position int
+		frets    string
+		chord    string
+	}{
+		{"m", ArpeggioSweep, 5, "5 7 7 5 5 5-8", "Am"},
+		{"", ArpeggioSweep, 4, "5 4 7 6 5 5", "A"},
+		{"m", ArpeggioStringSkip, 5, "5-8 7 5", "Am"},
+		{"7", ArpeggioSweep, 2, "3 4 2 2 2 3-5", "A7/G"},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, a := range GenerateArpeggios(A, qualityBySuffix(t, tt.suffix), tt.shape, StandardTuning, 15) {
+			if a.Position() == tt.position && a.String() == tt.frets {
+				found = true
+				if name := a.Chord().Name(DefaultKey(A, false)); name != tt.chord {
+					t.Errorf("%s reads as %s, want %s", tt.frets, name, tt.chord)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("A%s %s: no %q at fret %d", tt.suffix, tt.shape, tt.frets, tt.position)
+		}
+	}
+}
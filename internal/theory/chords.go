@@ -0,0 +1,199 @@
+package theory
+
+import (
+	"sort"
+)
+
+// ChordQuality is a chord type as intervals above its root
+type ChordQuality struct {
+	Suffix    string // Written after the root: "m7", "maj7", "7#9"
+	Intervals []int
+}
+
+// ChordQualities lists the recognized chords, most common first; the order
+// breaks ties when notes fit several chords (C6 and Am7/C)
+var ChordQualities = []ChordQuality{
+	{"", []int{0, 4, 7}},
+	{"m", []int{0, 3, 7}},
+	{"5", []int{0, 7}},
+	{"7", []int{0, 4, 7, 10}},
+	{"maj7", []int{0, 4, 7, 11}},
+	{"m7", []int{0, 3, 7, 10}},
+	{"sus4", []int{0, 5, 7}},
+	{"sus2", []int{0, 2, 7}},
+	{"dim", []int{0, 3, 6}},
+	{"aug", []int{0, 4, 8}},
+	{"6", []int{0, 4, 7, 9}},
+	{"m6", []int{0, 3, 7, 9}},
+	{"m7b5", []int{0, 3, 6, 10}},
+	{"dim7", []int{0, 3, 6, 9}},
+	{"mMaj7", []int{0, 3, 7, 11}},
+	{"7sus4", []int{0, 5, 7, 10}},
+	{"7b5", []int{0, 4, 6, 10}},
+	{"7#5", []int{0, 4, 8, 10}},
+	{"add9", []int{0, 2, 4, 7}},
+	{"madd9", []int{0, 2, 3, 7}},
+	{"9", []int{0, 2, 4, 7, 10}},
+	{"maj9", []int{0, 2, 4, 7, 11}},
+	{"m9", []int{0, 2, 3, 7, 10}},
+	{"6/9", []int{0, 2, 4, 7, 9}},
+	{"7b9", []int{0, 1, 4, 7, 10}},
+	{"7#9", []int{0, 3, 4, 7, 10}},
+	{"7#11", []int{0, 4, 6, 7, 10}},
+	{"maj7#11", []int{0, 4, 6, 7, 11}},
+	{"11", []int{0, 2, 5, 7, 10}},
+	{"m11", []int{0, 2, 3, 5, 7, 10}},
+	{"13", []int{0, 2, 4, 7, 9, 10}},
+}
+
+// Minor reports whether the chord has a minor 3rd and no major 3rd
+func (q ChordQuality) Minor() bool {
+	return containsInterval(q.Intervals, 3) && !containsInterval(q.Intervals, 4)
+}
+
+// ChordMatch is one reading of a set of notes as a chord
+type ChordMatch struct {
+	Root    Note
+	Bass    Note
+	Quality ChordQuality
+	Omitted []int // Chord intervals that are not played (the 5th)
+	rank    int
+}
+
+// IdentifyChord names the chord formed by notes with bass as the lowest
+// note. Matches are ranked best first: root in the bass, nothing omitted,
+// common chord types. Seventh and extended chords may leave out the 5th.
+func IdentifyChord(notes []Note, bass Note) []ChordMatch {
+	var played [12]bool
+	distinct := 0
+	for _, n := range append([]Note{bass}, notes...) {
+		if !played[n%12] {
+			played[n%12] = true
+			distinct++
+		}
+	}
+	if distinct < 2 {
+		return nil
+	}
+
+	var matches []ChordMatch
+	for root := Note(0); root < 12; root++ {
+		if !played[root] {
+			continue
+		}
+		for order, quality := range ChordQualities {
+			match, ok := matchQuality(played, root, quality)
+			if !ok {
+				continue
+			}
+			match.Bass = bass
+			match.rank = order + 100*len(match.Omitted)
+			if root != bass {
+				match.rank += 50
+			}
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank < matches[j].rank
+	})
+	return matches
+}
+
+// matchQuality checks that the played notes are exactly the chord on root,
+// allowing a missing 5th in chords of four notes or more
+func matchQuality(played [12]bool, root Note, quality ChordQuality) (ChordMatch, bool) {
+	match := ChordMatch{Root: root, Quality: quality}
+	var inChord [12]bool
+	for _, interval := range quality.Intervals {
+		inChord[interval%12] = true
+		if played[(int(root)+interval)%12] {
+			continue
+		}
+		if interval != 7 || len(quality.Intervals) < 4 {
+			return match, false
+		}
+		match.Omitted = append(match.Omitted, interval)
+	}
+	for n := 0; n < 12; n++ {
+		if played[n] && !inChord[(n-int(root)+12)%12] {
+			return match, false
+		}
+	}
+	return match, true
+}
+
+// Name spells the chord in key k: "Cmaj7", "Cmaj7/E", "A7#9", "E5"
+func (c ChordMatch) Name(k Key) string {
+	name := k.Spell(c.Root) + c.Quality.Suffix
+	if c.Bass != c.Root {
+		name += "/" + k.Spell(c.Bass)
+	}
+	return name
+}
+
+// Inversion returns 0 for root position, 1 with the 3rd (or sus note) in
+// the bass, 2 with the 5th, 3 with the 7th (or 6th), and -1 when the bass is
+// an extension or not in the chord
+func (c ChordMatch) Inversion() int {
+	bass := (int(c.Bass) - int(c.Root) + 12) % 12
+	if bass == 0 {
+		return 0
+	}
+	for inversion, candidates := range [][]int{{4, 3, 5, 2}, {7, 6, 8}, {10, 11, 9}} {
+		for _, interval := range candidates {
+			if c.hasInterval(interval) {
+				if interval == bass {
+					return inversion + 1
+				}
+				break
+			}
+		}
+	}
+	return -1
+}
+
+// InversionName describes the inversion: "root position", "1st inversion"
+func (c ChordMatch) InversionName() string {
+	return InversionName(c.Inversion())
+}
+
+// InversionName names an inversion number (see ChordMatch.Inversion)
+func InversionName(inversion int) string {
+	switch inversion {
+	case 0:
+		return "root position"
+	case 1:
+		return "1st inversion"
+	case 2:
+		return "2nd inversion"
+	case 3:
+		return "3rd inversion"
+	}
+	return "slash chord"
+}
+
+func (c ChordMatch) hasInterval(interval int) bool {
+	return containsInterval(c.Quality.Intervals, interval)
+}
+
+// Harmony returns the chord over the notes of key k, for interval labels
+func (c ChordMatch) Harmony(k Key) Harmony {
+	var tones []int
+	for _, interval := range c.Quality.Intervals {
+		if !containsInterval(c.Omitted, interval) {
+			tones = append(tones, interval)
+		}
+	}
+	var scale ScaleFormula
+	for _, sn := range k.Scale() {
+		scale = append(scale, (int(sn.Note())-int(c.Root)+12)%12)
+	}
+	for _, interval := range tones {
+		if !containsInterval(scale, interval) {
+			scale = append(scale, interval)
+		}
+	}
+	return Harmony{Root: c.Root, ChordTones: tones, Scale: scale}
+}
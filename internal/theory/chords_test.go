@@ -0,0 +1,48 @@
+package theory
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIdentifyChord(t *testing.T) {
+	tests := []struct {
+		key       string
+		notes     []Note
+		bass      Note
+		name      string
+		inversion int
+		omitted   []int
+	}{
+		{"C", []Note{C, E, G}, C, "C", 0, nil},
+		{"C", []Note{E, G, C}, E, "C/E", 1, nil},
+		{"C", []Note{C, E, G, B}, E, "Cmaj7/E", 1, nil},
+		{"C", []Note{G, B, D, F}, F, "G7/F", 3, nil},
+		{"F", []Note{C, E, As}, C, "C7", 0, []int{7}}, // No 5th
+		{"F", []Note{As, D, F}, As, "Bb", 0, nil},
+		{"C", []Note{A, C, E, G}, C, "C6", 0, nil}, // Root in the bass beats Am7/C
+		{"C", []Note{A, C, E, G}, A, "Am7", 0, nil},
+		{"E", []Note{E, B}, E, "E5", 0, nil},
+		{"C", []Note{C, Ds, Fs, A}, C, "Cdim7", 0, nil},
+		{"C", []Note{C, E, G}, D, "Cadd9/D", -1, nil}, // The 9th in the bass
+	}
+	for _, tt := range tests {
+		k, _ := ParseKey(tt.key)
+		matches := IdentifyChord(tt.notes, tt.bass)
+		if len(matches) == 0 {
+			t.Errorf("%s: no chord", tt.name)
+			continue
+		}
+		best := matches[0]
+		if name := best.Name(k); name != tt.name || best.Inversion() != tt.inversion || !slices.Equal(best.Omitted, tt.omitted) {
+			t.Errorf("got %s (inversion %d, omitted %v), want %s (inversion %d, omitted %v)",
+				name, best.Inversion(), best.Omitted, tt.name, tt.inversion, tt.omitted)
+		}
+	}
+
+	for _, notes := range [][]Note{{C}, {C, C}, {C, Cs, D}} {
+		if matches := IdentifyChord(notes, notes[0]); len(matches) > 0 {
+			t.Errorf("%v named %s", notes, matches[0].Name(DefaultKey(C, false)))
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package theory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// DefinitionsFile is the user definitions file read at startup
+const DefinitionsFile = "definitions.json"
+
+// Tunings are the named tunings, low string first
+var Tunings = map[string][]Note{"standard": StandardTuning}
+
+// TuningOrder là thứ tự duyệt các tuning trong UI
+var TuningOrder = []string{"standard"}
+
+// userPositions holds the position templates from definitions files, by
+// scale and position type, to show after the generated positions
+var userPositions = map[string]map[PositionType][]Position{}
+
+// Definitions is the content of a definitions file: extra scales, named
+// tunings and position templates
+type Definitions struct {
+	Scales    []ScaleDefinition    `json:"scales"`
+	Tunings   []TuningDefinition   `json:"tunings"`
+	Positions []PositionDefinition `json:"positions"`
+}
+
+// ScaleDefinition is a scale as semitones above the root: [0, 2, 3, 6, 7, 8, 11]
+type ScaleDefinition struct {
+	Name      string `json:"name"`
+	Intervals []int  `json:"intervals"`
+}
+
+// TuningDefinition is a tuning as open-string notes, low string first
+type TuningDefinition struct {
+	Name  string   `json:"name"`
+	Notes []string `json:"notes"`
+}
+
+// PositionDefinition is a position template for a scale. Frets and Fingers
+// are per string, low E first; frets are relative to StartOffset frets
+// above the root on the low E string, like NotePatterns.
+type PositionDefinition struct {
+	Scale       string  `json:"scale"`
+	Type        string  `json:"type"` // "caged" or "3nps"
+	StartOffset int     `json:"start_offset"`
+	Frets       [][]int `json:"frets"`
+	Fingers     [][]int `json:"fingers"`
+}
+
+// DefinitionError is a rejected entry of a definitions file
+type DefinitionError struct {
+	File   string
+	Entry  string // "scale hirajoshi", "tuning drop_d", "position 2"
+	Detail string
+}
+
+func (e DefinitionError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.File, e.Entry, e.Detail)
+}
+
+// LoadDefinitions reads a definitions file and registers its valid entries.
+// A missing file is not an error; each rejected entry is.
+func LoadDefinitions(path string) []error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return []error{err}
+	}
+	var defs Definitions
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return []error{fmt.Errorf("%s: %w", path, err)}
+	}
+	return RegisterDefinitions(defs, path)
+}
+
+// RegisterDefinitions validates the definitions and merges the valid ones
+// into Scales and ScaleOrder, Tunings and TuningOrder, and AllScalePositions.
+// Built-in scales and tunings cannot be replaced. Positions may use the
+// scales defined alongside them.
+func RegisterDefinitions(defs Definitions, source string) []error {
+	var errs []error
+	reject := func(entry, format string, args ...any) {
+		errs = append(errs, DefinitionError{File: source, Entry: entry, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	for _, d := range defs.Scales {
+		entry := "scale " + d.Name
+		if err := validateScale(d); err != "" {
+			reject(entry, "%s", err)
+			continue
+		}
+		Scales[d.Name] = ScaleFormula(d.Intervals)
+		ScaleOrder = append(ScaleOrder, d.Name)
+	}
+
+	for _, d := range defs.Tunings {
+		entry := "tuning " + d.Name
+		tuning, err := parseTuning(d)
+		if err != "" {
+			reject(entry, "%s", err)
+			continue
+		}
+		Tunings[d.Name] = tuning
+		TuningOrder = append(TuningOrder, d.Name)
+	}
+
+	for i, d := range defs.Positions {
+		entry := fmt.Sprintf("position %d (%s)", i+1, d.Scale)
+		pos, err := buildTemplate(d)
+		if err != "" {
+			reject(entry, "%s", err)
+			continue
+		}
+		sp, ok := AllScalePositions[d.Scale]
+		if !ok {
+			sp = ScalePositions{ScaleName: ScaleDisplayName(d.Scale)}
+		}
+		if pos.Type == PositionType3NPS {
+			pos.Index = len(sp.ThreeNPS) + 1
+			sp.ThreeNPS = append(sp.ThreeNPS, pos)
+		} else {
+			pos.Index = len(sp.CAGED) + 1
+			sp.CAGED = append(sp.CAGED, pos)
+		}
+		AllScalePositions[d.Scale] = sp
+
+		if userPositions[d.Scale] == nil {
+			userPositions[d.Scale] = map[PositionType][]Position{}
+		}
+		userPositions[d.Scale][pos.Type] = append(userPositions[d.Scale][pos.Type], pos)
+	}
+	return errs
+}
+
+// UserPositions returns the position templates defined for a scale
+func UserPositions(scale string, t PositionType) []Position {
+	return userPositions[scale][t]
+}
+
+// validateScale checks a scale definition; "" when it is valid
+func validateScale(d ScaleDefinition) string {
+	switch {
+	case d.Name == "":
+		return "missing name"
+	case Scales[d.Name] != nil:
+		return "a scale with this name already exists"
+	case len(d.Intervals) < 2:
+		return "needs at least two intervals"
+	case d.Intervals[0] != 0:
+		return "intervals must start at 0 (the root)"
+	}
+	for i, interval := range d.Intervals {
+		if interval < 0 || interval > 11 {
+			return fmt.Sprintf("interval %d is outside 0-11", interval)
+		}
+		if i > 0 && interval <= d.Intervals[i-1] {
+			return "intervals must be in ascending order without repeats"
+		}
+	}
+	return ""
+}
+
+// parseTuning reads a tuning definition; the error is "" when it is valid
+func parseTuning(d TuningDefinition) ([]Note, string) {
+	switch {
+	case d.Name == "":
+		return nil, "missing name"
+	case Tunings[d.Name] != nil:
+		return nil, "a tuning with this name already exists"
+	case len(d.Notes) != len(StandardTuning):
+		return nil, fmt.Sprintf("needs %d strings, has %d", len(StandardTuning), len(d.Notes))
+	}
+	tuning := make([]Note, len(d.Notes))
+	for i, s := range d.Notes {
+		n, ok := ParseNote(s)
+		if !ok {
+			return nil, fmt.Sprintf("string %d: unknown note %q", len(d.Notes)-i, s)
+		}
+		tuning[i] = n
+	}
+	return tuning, ""
+}
+
+// buildTemplate turns a position definition into a Position, checking its
+// shape and that every note is in the scale (root E on the open low string,
+// as ValidatePositions checks the built-in tables); the error is "" when it
+// is valid
+func buildTemplate(d PositionDefinition) (Position, string) {
+	if Scales[d.Scale] == nil {
+		return Position{}, fmt.Sprintf("unknown scale %q", d.Scale)
+	}
+	posType := PositionType(d.Type)
+	if posType != PositionTypeCAGED && posType != PositionType3NPS {
+		return Position{}, fmt.Sprintf("unknown type %q (caged, 3nps)", d.Type)
+	}
+	if d.StartOffset < 0 || d.StartOffset > 11 {
+		return Position{}, "start_offset must be 0-11"
+	}
+	if len(d.Frets) == 0 || len(d.Frets) > len(StandardTuning) {
+		return Position{}, fmt.Sprintf("frets needs 1-%d strings", len(StandardTuning))
+	}
+	if len(d.Fingers) != len(d.Frets) {
+		return Position{}, "fingers and frets have different numbers of strings"
+	}
+
+	pos := Position{Type: posType, StartOffset: d.StartOffset}
+	rootStrings := map[int]bool{}
+	for s, frets := range d.Frets {
+		if len(d.Fingers[s]) != len(frets) {
+			return Position{}, fmt.Sprintf("string %d: %d frets but %d fingers", 6-s, len(frets), len(d.Fingers[s]))
+		}
+		for i, rel := range frets {
+			if rel < 0 || i > 0 && rel <= frets[i-1] {
+				return Position{}, fmt.Sprintf("string %d: frets must be ascending and not negative", 6-s)
+			}
+			if f := d.Fingers[s][i]; f < 1 || f > 4 {
+				return Position{}, fmt.Sprintf("string %d: finger %d is not 1-4", 6-s, f)
+			}
+			note := CalculateNote(StandardTuning[s], d.StartOffset+rel)
+			if !IsNoteInScale(note, E, d.Scale) {
+				return Position{}, fmt.Sprintf("string %d fret %d (%s) is not in the scale", 6-s, d.StartOffset+rel, ScaleKey(E, d.Scale).Spell(note))
+			}
+			if note == E {
+				rootStrings[6-s] = true
+			}
+			pos.FretSpan = max(pos.FretSpan, rel+1)
+		}
+		pos.NotePatterns[s] = NotePattern{RelativeFrets: frets}
+		pos.FingerPattern[s] = d.Fingers[s]
+	}
+	for s := range rootStrings {
+		pos.RootStrings = append(pos.RootStrings, s)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(pos.RootStrings)))
+	return pos, ""
+}
@@ -0,0 +1,105 @@
+package theory
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// keepRegistry restores the scales, tunings and positions a test registers
+func keepRegistry(t *testing.T) {
+	scales, scaleOrder := maps.Clone(Scales), slices.Clone(ScaleOrder)
+	tunings, tuningOrder := maps.Clone(Tunings), slices.Clone(TuningOrder)
+	positions, user := maps.Clone(AllScalePositions), maps.Clone(userPositions)
+	t.Cleanup(func() {
+		Scales, ScaleOrder = scales, scaleOrder
+		Tunings, TuningOrder = tunings, tuningOrder
+		AllScalePositions, userPositions = positions, user
+	})
+}
+
+func TestLoadDefinitionsExample(t *testing.T) {
+	keepRegistry(t)
+	if errs := LoadDefinitions(filepath.Join("..", "..", "definitions.example.json")); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if !slices.Equal(Scales["hirajoshi"], ScaleFormula{0, 2, 3, 7, 8}) || ScaleOrder[len(ScaleOrder)-1] != "bhairav" {
+		t.Errorf("scales not registered: %v", ScaleOrder)
+	}
+	if !slices.Equal(Tunings["dadgad"], []Note{D, A, D, G, A, D}) || TuningOrder[0] != "standard" || len(TuningOrder) != 5 {
+		t.Errorf("tunings not registered: %v", TuningOrder)
+	}
+	// The template follows the generated boxes and passes the position check
+	if user := UserPositions("hirajoshi", PositionTypeCAGED); len(user) != 1 || user[0].FretSpan != 4 {
+		t.Errorf("hirajoshi templates: %+v", user)
+	}
+	for _, m := range ValidatePositions() {
+		t.Error(m)
+	}
+}
+
+func TestRegisterDefinitionsErrors(t *testing.T) {
+	keepRegistry(t)
+	defs := Definitions{
+		Scales: []ScaleDefinition{
+			{Name: "major", Intervals: []int{0, 2, 4}},
+			{Name: "rootless", Intervals: []int{2, 4, 7}},
+			{Name: "backwards", Intervals: []int{0, 7, 4}},
+			{Name: "wide", Intervals: []int{0, 12}},
+			{Name: "ok", Intervals: []int{0, 3, 7}},
+		},
+		Tunings: []TuningDefinition{
+			{Name: "standard", Notes: []string{"E", "A", "D", "G", "B", "E"}},
+			{Name: "four", Notes: []string{"E", "A", "D", "G"}},
+			{Name: "typo", Notes: []string{"E", "A", "D", "H", "B", "E"}},
+		},
+		Positions: []PositionDefinition{
+			{Scale: "nope", Type: "caged", Frets: [][]int{{0}}, Fingers: [][]int{{1}}},
+			{Scale: "ok", Type: "boxes", Frets: [][]int{{0}}, Fingers: [][]int{{1}}},
+			{Scale: "ok", Type: "caged", Frets: [][]int{{0, 3}}, Fingers: [][]int{{1, 5}}},
+			{Scale: "ok", Type: "caged", Frets: [][]int{{0, 2}}, Fingers: [][]int{{1, 3}}},
+		},
+	}
+	want := []string{
+		"scale major: a scale with this name already exists",
+		"scale rootless: intervals must start at 0 (the root)",
+		"scale backwards: intervals must be in ascending order without repeats",
+		"scale wide: interval 12 is outside 0-11",
+		"tuning standard: a tuning with this name already exists",
+		"tuning four: needs 6 strings, has 4",
+		`tuning typo: string 3: unknown note "H"`,
+		`position 1 (nope): unknown scale "nope"`,
+		`position 2 (ok): unknown type "boxes" (caged, 3nps)`,
+		"position 3 (ok): string 6: finger 5 is not 1-4",
+		"position 4 (ok): string 6 fret 2 (F#) is not in the scale",
+	}
+	errs := RegisterDefinitions(defs, "test.json")
+	if len(errs) != len(want) {
+		t.Fatalf("%d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if got := strings.TrimPrefix(err.Error(), "test.json: "); got != want[i] {
+			t.Errorf("error %d = %q, want %q", i+1, got, want[i])
+		}
+	}
+	// The valid scale is kept; the rejected ones are not
+	if Scales["ok"] == nil || Scales["rootless"] != nil || !slices.Equal(Scales["major"], ScaleFormula{0, 2, 4, 5, 7, 9, 11}) {
+		t.Error("the registry does not hold exactly the valid scale")
+	}
+}
+
+func TestLoadDefinitionsFiles(t *testing.T) {
+	keepRegistry(t)
+	dir := t.TempDir()
+	if errs := LoadDefinitions(filepath.Join(dir, "missing.json")); errs != nil {
+		t.Errorf("missing file: %v", errs)
+	}
+	bad := filepath.Join(dir, "bad.json")
+	os.WriteFile(bad, []byte(`{"scales": [`), 0o644)
+	if errs := LoadDefinitions(bad); len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), bad) {
+		t.Errorf("broken JSON: %v", errs)
+	}
+}
@@ -0,0 +1,105 @@
+package theory
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Weights of the key detection score
+const (
+	// unusedPenalty is taken off for each share of scale notes never played,
+	// so A minor pentatonic beats A natural minor on pentatonic licks
+	unusedPenalty = 0.3
+	// tonicBonus rewards roots that are heard a lot, so the same notes read
+	// as A minor rather than C major when A is the center
+	tonicBonus = 0.2
+	// confidentMargin is the score lead over the best other root that gives
+	// full confidence
+	confidentMargin = 0.2
+)
+
+// KeyGuess is a root and scale suggested for a set of notes
+type KeyGuess struct {
+	Root       Note
+	Scale      string  // theory.Scales key
+	Score      float64 // Ranking score
+	Fit        float64 // Share of the weighted notes inside the scale (0-1)
+	Confidence float64 // 0-1, see DetectKey
+}
+
+// Key spells the guess as a key (minor when the scale has a minor 3rd)
+func (g KeyGuess) Key() Key {
+	return ScaleKey(g.Root, g.Scale)
+}
+
+// String formats the guess: "A Minor Pentatonic (82%)"
+func (g KeyGuess) String() string {
+	return fmt.Sprintf("%s %s (%.0f%%)", g.Key().Tonic, ScaleDisplayName(g.Scale), g.Confidence*100)
+}
+
+// DetectKey scores every root and scale against how much each pitch class
+// is heard (weights, e.g. beats of duration) and returns the guesses best
+// first. A scale scores by the share of the music it holds, less a penalty
+// for its notes that never sound, plus a bonus for a prominent root.
+// Confidence is a guess's fit, lowered when a guess on another root scores
+// almost as well. Returns nil when nothing was played.
+func DetectKey(weights [12]float64) []KeyGuess {
+	total, loudest := 0.0, 0.0
+	for _, w := range weights {
+		total += w
+		loudest = max(loudest, w)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var guesses []KeyGuess
+	for _, name := range ScaleOrder {
+		formula, ok := Scales[name]
+		if !ok {
+			continue
+		}
+		for root := Note(0); root < 12; root++ {
+			inside, used := 0.0, 0
+			for _, interval := range formula {
+				w := weights[(int(root)+interval)%12]
+				inside += w
+				if w > 0 {
+					used++
+				}
+			}
+			fit := inside / total
+			unused := 1 - float64(used)/float64(len(formula))
+			guesses = append(guesses, KeyGuess{
+				Root:  root,
+				Scale: name,
+				Fit:   fit,
+				Score: fit - unusedPenalty*unused + tonicBonus*weights[root]/loudest,
+			})
+		}
+	}
+
+	sort.SliceStable(guesses, func(i, j int) bool {
+		return guesses[i].Score > guesses[j].Score
+	})
+
+	// Best score per root; each guess is measured against the best other root
+	var bestOf [12]float64
+	var seen [12]bool
+	for _, g := range guesses {
+		if !seen[g.Root] {
+			bestOf[g.Root], seen[g.Root] = g.Score, true
+		}
+	}
+	for i := range guesses {
+		rival := -1.0
+		for root := Note(0); root < 12; root++ {
+			if root != guesses[i].Root {
+				rival = max(rival, bestOf[root])
+			}
+		}
+		lead := (guesses[i].Score - rival) / confidentMargin
+		guesses[i].Confidence = guesses[i].Fit * max(0, min(1, 0.5+0.5*lead))
+	}
+	return guesses
+}
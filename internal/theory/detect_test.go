@@ -0,0 +1,62 @@
+package theory
+
+import "testing"
+
+// weightsOf gives every note a weight of 1, and the first one (the tonic) tonic
+func weightsOf(tonic float64, notes ...Note) [12]float64 {
+	var w [12]float64
+	for _, n := range notes {
+		w[n] = 1
+	}
+	w[notes[0]] = tonic
+	return w
+}
+
+func TestDetectKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights [12]float64
+		root    Note
+		scale   string
+		key     string
+	}{
+		{"A minor pentatonic lick", weightsOf(3, A, C, D, E, G), A, "minor_pentatonic", "Am"},
+		{"C major scale", weightsOf(2, C, D, E, F, G, A, B), C, "major", "C"},
+		{"same notes centered on A", weightsOf(2, A, B, C, D, E, F, G), A, "minor", "Am"},
+		{"E blues", weightsOf(2, E, G, A, As, B, D), E, "blues", "Em"},
+		{"D dorian", weightsOf(2, D, E, F, G, A, B, C), D, "dorian", "Dm"},
+		{"F# harmonic minor", weightsOf(2, Fs, Gs, A, B, Cs, D, F), Fs, "harmonic_minor", "F#m"},
+	}
+	for _, tt := range tests {
+		guesses := DetectKey(tt.weights)
+		if len(guesses) == 0 {
+			t.Errorf("%s: no guess", tt.name)
+			continue
+		}
+		g := guesses[0]
+		if g.Root != tt.root || g.Scale != tt.scale || g.Key().String() != tt.key {
+			t.Errorf("%s: %s (%s)", tt.name, g, g.Key())
+		}
+		if g.Fit != 1 || g.Confidence <= 0.5 {
+			t.Errorf("%s: fit %.2f, confidence %.2f", tt.name, g.Fit, g.Confidence)
+		}
+	}
+
+	if DetectKey([12]float64{}) != nil {
+		t.Error("a guess for silence")
+	}
+}
+
+func TestDetectKeyConfidence(t *testing.T) {
+	// The notes of C major with no center: C major and A minor tie
+	g := DetectKey(weightsOf(1, C, D, E, F, G, A, B))[0]
+	if g.Confidence > 0.5 {
+		t.Errorf("%s is a coin toss, confidence %.2f", g, g.Confidence)
+	}
+	// A passing note outside the scale lowers the fit, not the guess
+	w := weightsOf(3, A, C, D, E, G)
+	w[Cs] = 0.5
+	if g := DetectKey(w)[0]; g.Root != A || g.Scale != "minor_pentatonic" || g.Fit >= 1 || g.Confidence > g.Fit {
+		t.Errorf("passing C#: %s fit %.2f", g, g.Fit)
+	}
+}
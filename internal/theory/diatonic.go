@@ -0,0 +1,145 @@
+package theory
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ModeNames lists the modes of the major scale, in scale-degree order
+var ModeNames = []string{"ionian", "dorian", "phrygian", "lydian", "mixolydian", "aeolian", "locrian"}
+
+// romanNumerals are the scale degrees I-VII
+var romanNumerals = []string{"I", "II", "III", "IV", "V", "VI", "VII"}
+
+// DiatonicChord is the chord built on one degree of a key or mode
+type DiatonicChord struct {
+	Numeral string        // Relative to the tonic: "I", "ii", "bIII", "vii°"
+	Root    SpelledNote   // Root of the chord
+	Triad   ChordQuality  // "", "m", "dim" or "aug"
+	Seventh ChordQuality  // "maj7", "m7", "7", "m7b5"...
+	Tones   []SpelledNote // Root, 3rd, 5th, 7th
+}
+
+// Name is the chord symbol of the triad: "C", "Dm", "Bdim"
+func (c DiatonicChord) Name() string {
+	return c.Root.String() + c.Triad.Suffix
+}
+
+// Notes returns the pitch classes of the seventh chord
+func (c DiatonicChord) Notes() []Note {
+	notes := make([]Note, len(c.Tones))
+	for i, t := range c.Tones {
+		notes[i] = t.Note()
+	}
+	return notes
+}
+
+// ModeTonic is the first note of a mode of a major key: dorian of C is D
+func ModeTonic(parent Key, mode int) SpelledNote {
+	return SpellScale(parent.Tonic, Scales["major"])[mode%7]
+}
+
+// ModeName formats a mode of a major key: "D Dorian", "A Aeolian"
+func ModeName(parent Key, mode int) string {
+	name := ModeNames[mode%7]
+	return ModeTonic(parent, mode).String() + " " + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// ModeChords stacks thirds on every degree of a mode of the major key
+// parent. Numerals are relative to the mode's tonic, with flats and sharps
+// against its major scale: D dorian gives i ii bIII IV v vi° bVII.
+func ModeChords(parent Key, mode int) []DiatonicChord {
+	scale := SpellScale(parent.Tonic, Scales["major"])
+	tonic := scale[mode%7].Note()
+	major := Scales["major"]
+
+	chords := make([]DiatonicChord, 7)
+	for degree := range chords {
+		tone := func(step int) SpelledNote { return scale[(mode+degree+step)%7] }
+		root := tone(0)
+		intervals := []int{0}
+		tones := []SpelledNote{root}
+		for _, step := range []int{2, 4, 6} {
+			t := tone(step)
+			tones = append(tones, t)
+			intervals = append(intervals, (int(t.Note())-int(root.Note())+12)%12)
+		}
+
+		c := DiatonicChord{
+			Root:    root,
+			Triad:   qualityOf(intervals[:3]),
+			Seventh: qualityOf(intervals),
+			Tones:   tones,
+		}
+
+		numeral := romanNumerals[degree]
+		switch c.Triad.Suffix {
+		case "m":
+			numeral = strings.ToLower(numeral)
+		case "dim":
+			numeral = strings.ToLower(numeral) + "°"
+		case "aug":
+			numeral += "+"
+		}
+		switch offset := ((int(root.Note())-int(tonic)-major[degree])%12 + 12) % 12; offset {
+		case 1:
+			numeral = "#" + numeral
+		case 11:
+			numeral = "b" + numeral
+		}
+		c.Numeral = numeral
+		chords[degree] = c
+	}
+	return chords
+}
+
+// DiatonicChords returns the chords of a key: major keys as ionian, minor
+// keys as aeolian of their relative major
+func DiatonicChords(k Key) []DiatonicChord {
+	if k.Minor {
+		return ModeChords(k.Relative(), 5)
+	}
+	return ModeChords(k, 0)
+}
+
+// Relative returns the relative minor of a major key and the relative major
+// of a minor key: C <-> Am, Eb <-> Cm
+func (k Key) Relative() Key {
+	if k.Minor {
+		n := Note((int(k.Root()) + 3) % 12)
+		return Key{Tonic: spellWithLetter(n, (k.Tonic.Letter+2)%7)}
+	}
+	n := Note((int(k.Root()) + 9) % 12)
+	return Key{Tonic: spellWithLetter(n, (k.Tonic.Letter+5)%7), Minor: true}
+}
+
+// SignatureString writes the key signature: "2♯", "3♭", "no ♯/♭"
+func (k Key) SignatureString() string {
+	switch sig := k.Signature(); {
+	case sig > 0:
+		return fmt.Sprintf("%d♯", sig)
+	case sig < 0:
+		return fmt.Sprintf("%d♭", -sig)
+	}
+	return "no ♯/♭"
+}
+
+// qualityOf finds the chord type with exactly these intervals
+func qualityOf(intervals []int) ChordQuality {
+	for _, q := range ChordQualities {
+		if len(q.Intervals) != len(intervals) {
+			continue
+		}
+		match := true
+		for _, interval := range intervals {
+			if !containsInterval(q.Intervals, interval) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return q
+		}
+	}
+	return ChordQuality{Intervals: intervals}
+}
@@ -0,0 +1,57 @@
+package theory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModeChords(t *testing.T) {
+	tests := []struct {
+		parent   string
+		mode     int
+		name     string
+		numerals string
+		chords   string
+	}{
+		{"C", 0, "C Ionian", "I ii iii IV V vi vii°", "Cmaj7 Dm7 Em7 Fmaj7 G7 Am7 Bm7b5"},
+		{"C", 1, "D Dorian", "i ii bIII IV v vi° bVII", "Dm7 Em7 Fmaj7 G7 Am7 Bm7b5 Cmaj7"},
+		{"C", 3, "F Lydian", "I II iii #iv° V vi vii", "Fmaj7 G7 Am7 Bm7b5 Cmaj7 Dm7 Em7"},
+		{"Eb", 5, "C Aeolian", "i ii° bIII iv v bVI bVII", "Cm7 Dm7b5 Ebmaj7 Fm7 Gm7 Abmaj7 Bb7"},
+		{"F#", 4, "C# Mixolydian", "I ii iii° IV v vi bVII", "C#7 D#m7 E#m7b5 F#maj7 G#m7 A#m7 Bmaj7"},
+	}
+	for _, tt := range tests {
+		parent, _ := ParseKey(tt.parent)
+		if name := ModeName(parent, tt.mode); name != tt.name {
+			t.Errorf("mode %d of %s = %s, want %s", tt.mode, tt.parent, name, tt.name)
+		}
+		var numerals, chords []string
+		for _, c := range ModeChords(parent, tt.mode) {
+			numerals = append(numerals, c.Numeral)
+			chords = append(chords, c.Root.String()+c.Seventh.Suffix)
+		}
+		if got := strings.Join(numerals, " "); got != tt.numerals {
+			t.Errorf("%s numerals %s, want %s", tt.name, got, tt.numerals)
+		}
+		if got := strings.Join(chords, " "); got != tt.chords {
+			t.Errorf("%s chords %s, want %s", tt.name, got, tt.chords)
+		}
+	}
+}
+
+func TestRelativeKey(t *testing.T) {
+	tests := []struct{ key, relative, signature string }{
+		{"C", "Am", "no ♯/♭"},
+		{"Eb", "Cm", "3♭"},
+		{"F#m", "A", "3♯"},
+		{"Gb", "Ebm", "6♭"},
+	}
+	for _, tt := range tests {
+		k, _ := ParseKey(tt.key)
+		if got := k.Relative().String(); got != tt.relative {
+			t.Errorf("relative of %s = %s, want %s", tt.key, got, tt.relative)
+		}
+		if got := k.SignatureString(); got != tt.signature {
+			t.Errorf("%s signature %s, want %s", tt.key, got, tt.signature)
+		}
+	}
+}
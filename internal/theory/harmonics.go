@@ -0,0 +1,42 @@
+package theory
+
+import "math"
+
+const (
+	// maxHarmonicPartial is the highest overtone looked for at a node
+	maxHarmonicPartial = 8
+	// harmonicNodeTolerance is how far (in frets) a written node may be from the true node
+	harmonicNodeTolerance = 0.2
+)
+
+// HarmonicPartial returns which partial sounds when the string is touched
+// node frets above the point where it is stopped: 12 -> 2, 7 or 19 -> 3,
+// 5 -> 4, 3.2 -> 6. ok is false when node is not close to a harmonic node.
+func HarmonicPartial(node float64) (partial int, ok bool) {
+	if node <= 0 {
+		return 0, false
+	}
+	// Touch point as a fraction of the vibrating length
+	x := 1 - math.Pow(2, -node/12)
+
+	for n := 2; n <= maxHarmonicPartial; n++ {
+		k := int(math.Round(x * float64(n)))
+		if k < 1 || k >= n {
+			continue
+		}
+		nodeFret := -12 * math.Log2(1-float64(k)/float64(n))
+		if math.Abs(nodeFret-node) <= harmonicNodeTolerance {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// HarmonicInterval returns how many semitones the partial sounds above the
+// stopped note, rounded to equal temperament (2 -> 12, 3 -> 19, 4 -> 24)
+func HarmonicInterval(partial int) int {
+	if partial < 1 {
+		return 0
+	}
+	return int(math.Round(12 * math.Log2(float64(partial))))
+}
@@ -0,0 +1,32 @@
+package theory
+
+import "testing"
+
+func TestHarmonicPartial(t *testing.T) {
+	tests := []struct {
+		node     float64
+		partial  int
+		ok       bool
+		interval int // Semitones above the stopped note
+	}{
+		{12, 2, true, 12},
+		{7, 3, true, 19},
+		{19, 3, true, 19},
+		{5, 4, true, 24},
+		{24, 4, true, 24},
+		{3.9, 5, true, 28},
+		{9, 5, true, 28},
+		{3.2, 6, true, 31},
+		{10, 0, false, 0},
+		{0, 0, false, 0},
+	}
+	for _, tt := range tests {
+		partial, ok := HarmonicPartial(tt.node)
+		if partial != tt.partial || ok != tt.ok {
+			t.Errorf("HarmonicPartial(%g) = %d, %v, want %d, %v", tt.node, partial, ok, tt.partial, tt.ok)
+		}
+		if ok && HarmonicInterval(partial) != tt.interval {
+			t.Errorf("partial %d is %d semitones up, want %d", partial, HarmonicInterval(partial), tt.interval)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package theory
+
+// IntervalNames labels the 12 intervals above a root
+var IntervalNames = [12]string{"R", "b2", "2", "b3", "3", "4", "b5", "5", "b6", "6", "b7", "7"}
+
+// tensionNames labels non-chord tones as chord extensions: 2 -> 9, 4 -> 11, 6 -> 13
+var tensionNames = [12]string{"R", "b9", "9", "#9", "3", "11", "#11", "5", "b13", "13", "b7", "7"}
+
+// IntervalName returns the interval from root up to n: "R", "b3", "5"
+func IntervalName(root, n Note) string {
+	return IntervalNames[(int(n)-int(root)+12)%12]
+}
+
+// ToneFunction is the role of a note against the harmony it is played over
+type ToneFunction int
+
+const (
+	ToneChord   ToneFunction = iota // Chord tone: R, 3rd, 5th, 7th
+	ToneTension                     // Scale note that colors the chord: 9, 11, 13
+	ToneAvoid                       // Scale note a half step above a chord tone (4 over a major chord)
+	ToneOutside                     // Not in the scale
+)
+
+// Harmony is what notes are heard against: a chord (as intervals above its
+// root) and the scale around it
+type Harmony struct {
+	Root       Note
+	ChordTones []int        // Semitones above Root
+	Scale      ScaleFormula // Semitones above Root
+}
+
+// KeyHarmony is the tonic seventh chord of a key over its diatonic scale:
+// Cmaj7 over C major, Am7 over A natural minor
+func KeyHarmony(k Key) Harmony {
+	if k.Minor {
+		return Harmony{Root: k.Root(), ChordTones: []int{0, 3, 7, 10}, Scale: Scales["minor"]}
+	}
+	return Harmony{Root: k.Root(), ChordTones: []int{0, 4, 7, 11}, Scale: Scales["major"]}
+}
+
+// Function classifies n against the harmony
+func (h Harmony) Function(n Note) ToneFunction {
+	interval := (int(n) - int(h.Root) + 12) % 12
+	if containsInterval(h.ChordTones, interval) {
+		return ToneChord
+	}
+	if !containsInterval(h.Scale, interval) {
+		return ToneOutside
+	}
+	if containsInterval(h.ChordTones, (interval+11)%12) {
+		return ToneAvoid
+	}
+	return ToneTension
+}
+
+// Label names n by its function: chord tones as intervals (R, b3, 5, b7),
+// other notes as extensions (9, 11, b13); a raised 2nd over a major 3rd is #9
+func (h Harmony) Label(n Note) string {
+	interval := (int(n) - int(h.Root) + 12) % 12
+	if containsInterval(h.ChordTones, interval) {
+		return IntervalNames[interval]
+	}
+	if interval == 3 && !containsInterval(h.ChordTones, 4) {
+		return IntervalNames[interval]
+	}
+	return tensionNames[interval]
+}
+
+func containsInterval(intervals []int, interval int) bool {
+	for _, i := range intervals {
+		if i%12 == interval {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package theory
+
+import "testing"
+
+func TestHarmonyFunctionAndLabel(t *testing.T) {
+	cmaj7 := KeyHarmony(DefaultKey(C, false))
+	am7 := KeyHarmony(DefaultKey(A, true))
+	tests := []struct {
+		name     string
+		harmony  Harmony
+		note     Note
+		function ToneFunction
+		label    string
+	}{
+		{"root of Cmaj7", cmaj7, C, ToneChord, "R"},
+		{"7th of Cmaj7", cmaj7, B, ToneChord, "7"},
+		{"D over Cmaj7", cmaj7, D, ToneTension, "9"},
+		{"F over Cmaj7", cmaj7, F, ToneAvoid, "11"},
+		{"A over Cmaj7", cmaj7, A, ToneTension, "13"},
+		{"F# over Cmaj7", cmaj7, Fs, ToneOutside, "#11"},
+		{"Eb over Cmaj7", cmaj7, Ds, ToneOutside, "#9"},
+		{"b3 of Am7", am7, C, ToneChord, "b3"},
+		{"B over Am7", am7, B, ToneTension, "9"},
+		{"F over Am7", am7, F, ToneAvoid, "b13"},
+	}
+	for _, tt := range tests {
+		if got := tt.harmony.Function(tt.note); got != tt.function {
+			t.Errorf("%s: function %d, want %d", tt.name, got, tt.function)
+		}
+		if got := tt.harmony.Label(tt.note); got != tt.label {
+			t.Errorf("%s: label %q, want %q", tt.name, got, tt.label)
+		}
+	}
+}
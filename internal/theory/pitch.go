@@ -0,0 +1,75 @@
+package theory
+
+import (
+	"fmt"
+	"math"
+)
+
+// Pitch là số MIDI: biết cả nốt lẫn quãng tám (C4 = 60, A4 = 69 = 440 Hz)
+type Pitch int
+
+// NewPitch builds a pitch from a pitch class and a scientific octave number
+func NewPitch(n Note, octave int) Pitch {
+	return Pitch((octave+1)*12 + int(n))
+}
+
+// Note returns the pitch class (0-11)
+func (p Pitch) Note() Note {
+	return Note((int(p)%12 + 12) % 12)
+}
+
+// Octave returns the scientific octave number (E2 = low E string)
+func (p Pitch) Octave() int {
+	return int(math.Floor(float64(p)/12)) - 1
+}
+
+// Frequency returns the pitch in Hz (A4 = 440 Hz, equal temperament)
+func (p Pitch) Frequency() float64 {
+	return 440 * math.Pow(2, float64(int(p)-69)/12)
+}
+
+// Transpose shifts the pitch by semitones
+func (p Pitch) Transpose(semitones int) Pitch {
+	return p + Pitch(semitones)
+}
+
+// String formats the pitch as note name plus octave, e.g. "A3", "C#4".
+// A pitch alone has no key, so this always uses sharps; anything shown to
+// the player goes through Spell with the lesson's key instead.
+func (p Pitch) String() string {
+	return fmt.Sprintf("%s%d", NoteNames[p.Note()], p.Octave())
+}
+
+// Spell formats the pitch with the key's spelling. The octave follows the
+// letter, so the pitch of C4 spelled as B# is "B#3".
+func (p Pitch) Spell(k Key) string {
+	sn := k.SpellNote(p.Note())
+	letterPitch := p - Pitch(sn.Accidental)
+	return fmt.Sprintf("%s%d", sn, letterPitch.Octave())
+}
+
+// StandardTuningPitches là cao độ dây buông chuẩn: E2 A2 D3 G3 B3 E4
+var StandardTuningPitches = TuningPitches(StandardTuning)
+
+// TuningPitches gives each open string its octave: the lowest string sits in
+// octave 2 and every next string is the nearest pitch above the previous one
+func TuningPitches(tuning []Note) []Pitch {
+	pitches := make([]Pitch, len(tuning))
+	for s, n := range tuning {
+		if s == 0 {
+			pitches[s] = NewPitch(n, 2)
+			continue
+		}
+		interval := (int(n) - int(pitches[s-1].Note()) + 12) % 12
+		if interval == 0 {
+			interval = 12
+		}
+		pitches[s] = pitches[s-1].Transpose(interval)
+	}
+	return pitches
+}
+
+// CalculatePitch tính cao độ thực từ dây buông và phím
+func CalculatePitch(openString Pitch, fret int) Pitch {
+	return openString.Transpose(fret)
+}
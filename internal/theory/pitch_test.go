@@ -0,0 +1,56 @@
+package theory
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPitch(t *testing.T) {
+	tests := []struct {
+		pitch  Pitch
+		note   Note
+		octave int
+		name   string
+		hz     float64
+	}{
+		{NewPitch(A, 4), A, 4, "A4", 440},
+		{NewPitch(E, 2), E, 2, "E2", 82.41},
+		{NewPitch(C, 4), C, 4, "C4", 261.63},
+		{NewPitch(As, 3), As, 3, "A#3", 233.08},
+		{NewPitch(B, -1), B, -1, "B-1", 15.43},
+	}
+	for _, tt := range tests {
+		if tt.pitch.Note() != tt.note || tt.pitch.Octave() != tt.octave || tt.pitch.String() != tt.name {
+			t.Errorf("pitch %d = %s octave %d (%s), want %s", int(tt.pitch), NoteNames[tt.pitch.Note()], tt.pitch.Octave(), tt.pitch, tt.name)
+		}
+		if hz := tt.pitch.Frequency(); math.Abs(hz-tt.hz) > 0.01 {
+			t.Errorf("%s = %.2f Hz, want %.2f", tt.name, hz, tt.hz)
+		}
+	}
+	if p := NewPitch(B, 3).Transpose(1); p != NewPitch(C, 4) {
+		t.Errorf("B3 + 1 = %s, want C4", p)
+	}
+}
+
+func TestTuningPitches(t *testing.T) {
+	tests := []struct {
+		name   string
+		tuning []Note
+		want   []string
+	}{
+		{"standard", StandardTuning, []string{"E2", "A2", "D3", "G3", "B3", "E4"}},
+		{"drop D", []Note{D, A, D, G, B, E}, []string{"D2", "A2", "D3", "G3", "B3", "E4"}},
+		{"open G", []Note{D, G, D, G, B, D}, []string{"D2", "G2", "D3", "G3", "B3", "D4"}},
+		{"unison strings", []Note{E, E, E}, []string{"E2", "E3", "E4"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, p := range TuningPitches(tt.tuning) {
+			got = append(got, p.String())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
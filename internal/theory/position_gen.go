@@ -0,0 +1,328 @@
+package theory
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// cagedPositions is how many CAGED positions cover the neck
+	cagedPositions = 5
+	// handSpan is the number of frets the hand covers without moving
+	handSpan = 4
+	// anchorFret puts the root far from the nut while generating, so no fret goes negative
+	anchorFret = 12
+)
+
+// placedNote is a scale note placed on the neck while building a position
+type placedNote struct {
+	stringIdx int
+	fret      int
+	interval  int // semitones above the root (0-11)
+}
+
+// GenerateThreeNPS computes the three-notes-per-string positions of a scale,
+// one starting on each scale degree of the lowest string
+func GenerateThreeNPS(formula ScaleFormula, tuning []Note) []Position {
+	var positions []Position
+	for degree := range formula {
+		notes := walkScale(formula, tuning, degree, func(_ []placedNote, onString int, _ int) bool {
+			return onString < 3
+		})
+		pos := buildPosition(notes, PositionType3NPS, degree+1, len(tuning), false)
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// GenerateCAGED computes up to five box positions of a scale. Each box starts
+// on a scale degree of the lowest string and keeps the hand within four
+// frets, reaching one fret back where the next string needs it. Pentatonic
+// scales give the classic five boxes; longer scales start their boxes on the
+// degrees of the matching pentatonic, like the CAGED shapes. Two degrees a
+// semitone apart (hirajoshi's 2 and b3) would start boxes in the same hand
+// position, so a box starting on the same fret as the one before is dropped.
+func GenerateCAGED(formula ScaleFormula, tuning []Note) []Position {
+	var positions []Position
+	for _, degree := range cagedStartDegrees(formula) {
+		start := anchorFret + formula[degree]
+		notes := walkScale(formula, tuning, degree, func(_ []placedNote, _ int, fret int) bool {
+			return fret <= start+handSpan-1
+		})
+		pos := buildPosition(notes, PositionTypeCAGED, len(positions)+1, len(tuning), true)
+		if n := len(positions); n > 0 && positions[n-1].StartOffset == pos.StartOffset {
+			continue
+		}
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// cagedStartDegrees picks the scale degrees the boxes start on: every degree
+// for scales of up to five notes, otherwise the degrees nearest the
+// pentatonic (major or minor, by the scale's third)
+func cagedStartDegrees(formula ScaleFormula) []int {
+	if len(formula) <= cagedPositions {
+		degrees := make([]int, len(formula))
+		for i := range degrees {
+			degrees[i] = i
+		}
+		return degrees
+	}
+
+	pentatonic := Scales["minor_pentatonic"]
+	for _, interval := range formula {
+		if interval == 4 {
+			pentatonic = Scales["major_pentatonic"]
+			break
+		}
+	}
+
+	var degrees []int
+	used := make(map[int]bool)
+	for _, target := range pentatonic {
+		best, bestDist := -1, 12
+		for d, interval := range formula {
+			dist := interval - target
+			if dist < 0 {
+				dist = -dist
+			}
+			if !used[d] && dist < bestDist {
+				best, bestDist = d, dist
+			}
+		}
+		if best >= 0 {
+			used[best] = true
+			degrees = append(degrees, best)
+		}
+	}
+	sort.Ints(degrees)
+	return degrees
+}
+
+// walkScale places the scale in ascending order starting at degree on the
+// lowest string (root at anchorFret). stay decides whether the next note
+// fits on the current string; otherwise it moves to the next string.
+func walkScale(formula ScaleFormula, tuning []Note, degree int, stay func(notes []placedNote, onString, fret int) bool) []placedNote {
+	opens := TuningPitches(tuning)
+	root := opens[0].Transpose(anchorFret)
+
+	var notes []placedNote
+	stringIdx, onString := 0, 0
+	for step := degree; ; step++ {
+		octave, d := step/len(formula), step%len(formula)
+		pitch := root.Transpose(octave*12 + formula[d])
+		fret := int(pitch - opens[stringIdx])
+
+		if len(notes) > 0 && !stay(notes, onString, fret) {
+			stringIdx++
+			onString = 0
+			if stringIdx >= len(opens) {
+				break
+			}
+			fret = int(pitch - opens[stringIdx])
+		}
+		notes = append(notes, placedNote{stringIdx: stringIdx, fret: fret, interval: formula[d]})
+		onString++
+	}
+	return notes
+}
+
+// buildPosition turns placed notes into a Position relative to the root on
+// the lowest string. handFixed uses one hand position for the whole box;
+// otherwise the hand moves with each string (3NPS).
+func buildPosition(notes []placedNote, posType PositionType, index, numStrings int, handFixed bool) Position {
+	minFret, maxFret := notes[0].fret, notes[0].fret
+	for _, n := range notes {
+		minFret = min(minFret, n.fret)
+		maxFret = max(maxFret, n.fret)
+	}
+
+	pos := Position{
+		Index:       index,
+		Type:        posType,
+		FretSpan:    maxFret - minFret + 1,
+		StartOffset: ((minFret-anchorFret)%12 + 12) % 12,
+	}
+
+	hand := notes[0].fret
+	rootStrings := make(map[int]bool)
+	for s := 0; s < numStrings && s < 6; s++ {
+		var frets []int
+		for _, n := range notes {
+			if n.stringIdx != s {
+				continue
+			}
+			frets = append(frets, n.fret)
+			if n.interval == 0 {
+				rootStrings[6-s] = true
+			}
+		}
+		if len(frets) == 0 {
+			continue
+		}
+		if !handFixed {
+			hand = frets[0]
+		}
+		rel := make([]int, len(frets))
+		for i, f := range frets {
+			rel[i] = f - minFret
+		}
+		pos.NotePatterns[s] = NotePattern{RelativeFrets: rel}
+		pos.FingerPattern[s] = assignStringFingers(frets, hand)
+	}
+
+	for s := range rootStrings {
+		pos.RootStrings = append(pos.RootStrings, s)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(pos.RootStrings)))
+	return pos
+}
+
+// assignStringFingers gives one finger per fret on a string with the index
+// finger on hand: fret hand+k uses finger k+1, the index reaches back one
+// fret and the pinky stretches when the string needs more than four fingers
+func assignStringFingers(frets []int, hand int) []int {
+	fingers := make([]int, len(frets))
+	for i, f := range frets {
+		fingers[i] = max(1, f-hand+1)
+		if i > 0 {
+			fingers[i] = max(fingers[i], fingers[i-1]+1)
+		}
+	}
+	// Compress from the pinky down when the string runs past finger 4
+	for i := len(fingers) - 1; i >= 0; i-- {
+		limit := 4
+		if i < len(fingers)-1 {
+			limit = fingers[i+1] - 1
+		}
+		fingers[i] = max(1, min(fingers[i], limit))
+	}
+	return fingers
+}
+
+// PositionCells returns the absolute (string index, fret) cells of a position
+// whose lowest-string root is on rootFret
+func PositionCells(pos Position, rootFret int) [][2]int {
+	start, _ := CalculateFretRange(pos, rootFret)
+	var cells [][2]int
+	for s, pattern := range pos.NotePatterns {
+		for _, rel := range pattern.RelativeFrets {
+			cells = append(cells, [2]int{s, start + rel})
+		}
+	}
+	return cells
+}
+
+// PositionMismatch is one difference between a hand-written position and the generated one
+type PositionMismatch struct {
+	Scale  string
+	Type   PositionType
+	Index  int
+	Detail string
+}
+
+func (m PositionMismatch) String() string {
+	system := "CAGED"
+	if m.Type == PositionType3NPS {
+		system = "3NPS"
+	}
+	return fmt.Sprintf("%s %s %d: %s", m.Scale, system, m.Index, m.Detail)
+}
+
+// ValidatePositions checks the hand-written CAGED and 3NPS tables in
+// AllScalePositions against GenerateCAGED and GenerateThreeNPS in standard
+// tuning with an E root: notes outside the scale, and cells missing from or
+// extra to the closest generated position. Templates from the definitions
+// file are checked when they load, not here.
+func ValidatePositions() []PositionMismatch {
+	var mismatches []PositionMismatch
+
+	names := make([]string, 0, len(AllScalePositions))
+	for name := range AllScalePositions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		formula, ok := Scales[name]
+		if !ok {
+			mismatches = append(mismatches, PositionMismatch{Scale: name, Detail: "no scale formula"})
+			continue
+		}
+		sp := AllScalePositions[name]
+		mismatches = append(mismatches, comparePositions(name, PositionTypeCAGED, sp.CAGED, GenerateCAGED(formula, StandardTuning))...)
+		mismatches = append(mismatches, comparePositions(name, PositionType3NPS, sp.ThreeNPS, GenerateThreeNPS(formula, StandardTuning))...)
+	}
+
+	return mismatches
+}
+
+// comparePositions checks the built-in positions of one system against the
+// generated ones
+func comparePositions(name string, posType PositionType, written, generated []Position) []PositionMismatch {
+	var mismatches []PositionMismatch
+	key := ScaleKey(E, name)
+	// User templates are appended after the built-in positions
+	builtIn := written[:len(written)-len(UserPositions(name, posType))]
+
+	for _, pos := range builtIn {
+		add := func(format string, args ...any) {
+			mismatches = append(mismatches, PositionMismatch{
+				Scale: name, Type: posType, Index: pos.Index, Detail: fmt.Sprintf(format, args...),
+			})
+		}
+
+		cells := cellSet(PositionCells(pos, 0))
+		for c := range cells {
+			note := CalculateNote(StandardTuning[c[0]], c[1])
+			if !IsNoteInScale(note, E, name) {
+				add("string %d fret %d (%s) is not in the scale", 6-c[0], c[1], key.Spell(note))
+			}
+		}
+
+		best := closestPosition(cells, generated)
+		if best == nil {
+			add("no generated position")
+			continue
+		}
+		want := cellSet(PositionCells(*best, 0))
+		for c := range want {
+			if !cells[c] {
+				add("missing string %d fret %d (generated position %d)", 6-c[0], c[1], best.Index)
+			}
+		}
+		for c := range cells {
+			if !want[c] {
+				add("extra string %d fret %d (generated position %d)", 6-c[0], c[1], best.Index)
+			}
+		}
+	}
+	return mismatches
+}
+
+func cellSet(cells [][2]int) map[[2]int]bool {
+	set := make(map[[2]int]bool, len(cells))
+	for _, c := range cells {
+		set[c] = true
+	}
+	return set
+}
+
+// closestPosition returns the generated position sharing the most cells with cells
+func closestPosition(cells map[[2]int]bool, generated []Position) *Position {
+	var best *Position
+	bestShared := -1
+	for i := range generated {
+		shared := 0
+		for c := range cellSet(PositionCells(generated[i], 0)) {
+			if cells[c] {
+				shared++
+			}
+		}
+		if shared > bestShared {
+			best, bestShared = &generated[i], shared
+		}
+	}
+	return best
+}
@@ -0,0 +1,61 @@
+package theory
+
+import "testing"
+
+func TestValidatePositions(t *testing.T) {
+	for _, m := range ValidatePositions() {
+		t.Error(m)
+	}
+}
+
+func TestGenerateCAGED(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula ScaleFormula
+		offsets []int // StartOffset of each box, E root on the open low string
+	}{
+		{"minor pentatonic", Scales["minor_pentatonic"], []int{0, 2, 4, 7, 9}},
+		{"major pentatonic", Scales["major_pentatonic"], []int{11, 1, 4, 6, 9}},
+		{"major", Scales["major"], []int{11, 1, 4, 6, 8}},
+		{"minor", Scales["minor"], []int{11, 2, 4, 7, 9}},
+		// 2 and b3, 5 and b6 start in the same hand position: one box each
+		{"hirajoshi", ScaleFormula{0, 2, 3, 7, 8}, []int{11, 2, 7}},
+	}
+	for _, tt := range tests {
+		positions := GenerateCAGED(tt.formula, StandardTuning)
+		if len(positions) != len(tt.offsets) {
+			t.Errorf("%s: %d boxes, want %d", tt.name, len(positions), len(tt.offsets))
+			continue
+		}
+		for i, pos := range positions {
+			if pos.Index != i+1 || pos.StartOffset != tt.offsets[i] {
+				t.Errorf("%s box %d: index %d offset %d, want offset %d", tt.name, i+1, pos.Index, pos.StartOffset, tt.offsets[i])
+			}
+			if pos.FretSpan > handSpan+1 {
+				t.Errorf("%s box %d spans %d frets", tt.name, i+1, pos.FretSpan)
+			}
+			for _, c := range PositionCells(pos, 0) {
+				if note := CalculateNote(StandardTuning[c[0]], c[1]); !containsInterval(tt.formula, (int(note)-int(E)+12)%12) {
+					t.Errorf("%s box %d: string %d fret %d is not in the scale", tt.name, i+1, 6-c[0], c[1])
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateThreeNPS(t *testing.T) {
+	for _, name := range []string{"major", "minor", "minor_pentatonic"} {
+		formula := Scales[name]
+		positions := GenerateThreeNPS(formula, StandardTuning)
+		if len(positions) != len(formula) {
+			t.Errorf("%s: %d positions, want one per degree (%d)", name, len(positions), len(formula))
+		}
+		for _, pos := range positions {
+			for s, pattern := range pos.NotePatterns {
+				if len(pattern.RelativeFrets) != 3 {
+					t.Errorf("%s 3NPS %d: string %d has %d notes", name, pos.Index, 6-s, len(pattern.RelativeFrets))
+				}
+			}
+		}
+	}
+}
@@ -53,76 +53,167 @@ var AllScalePositions = map[string]ScalePositions{
 				Index:       2,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{2, 4},
-				StartOffset: 3,
+				RootStrings: []int{4, 2},
+				StartOffset: 2,
 				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
 					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 2, 4}, {1, 3}, {1, 3}, {1, 3}, {1, 3},
+					{1, 3}, {1, 3}, {1, 3}, {1, 2}, {1, 3}, {1, 3},
 				},
 			},
 			{
 				Index:       3,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{2, 5},
-				StartOffset: 5,
+				FretSpan:    5,
+				RootStrings: []int{5, 2},
+				StartOffset: 4,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{1, 4}},
+					{RelativeFrets: []int{1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 3}, {1, 2, 4}, {1, 3}, {1, 3}, {1, 3},
+					{1, 3}, {1, 3}, {1, 3}, {1, 3}, {1, 4}, {1, 3},
 				},
 			},
 			{
 				Index:       4,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{3, 5},
+				RootStrings: []int{5, 3},
 				StartOffset: 7,
 				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
 					{RelativeFrets: []int{0, 2}},
 					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 3}, {1, 3}, {1, 2, 4}, {1, 3}, {1, 3},
+					{1, 4}, {1, 4}, {1, 3}, {1, 3}, {2, 4}, {1, 4},
 				},
 			},
 			{
 				Index:       5,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{1, 3, 6},
+				RootStrings: []int{6, 3, 1},
+				StartOffset: 9,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3}, {1, 3}, {1, 3}, {1, 3}, {1, 3}, {1, 3},
+				},
+			},
+		},
+		ThreeNPS: []Position{
+			{
+				Index:       1,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{6, 5, 3, 1},
+				StartOffset: 0,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 3, 5}},
+					{RelativeFrets: []int{2, 5, 7}},
+					{RelativeFrets: []int{5, 7, 9}},
+					{RelativeFrets: []int{7, 9, 12}},
+					{RelativeFrets: []int{10, 12, 15}},
+					{RelativeFrets: []int{12, 15, 17}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       2,
+				Type:        PositionType3NPS,
+				FretSpan:    17,
+				RootStrings: []int{5, 3, 2},
+				StartOffset: 3,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{2, 4, 7}},
+					{RelativeFrets: []int{4, 6, 9}},
+					{RelativeFrets: []int{6, 9, 11}},
+					{RelativeFrets: []int{9, 12, 14}},
+					{RelativeFrets: []int{12, 14, 16}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       3,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{5, 4, 2},
+				StartOffset: 5,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 5}},
+					{RelativeFrets: []int{2, 5, 7}},
+					{RelativeFrets: []int{4, 7, 9}},
+					{RelativeFrets: []int{7, 9, 11}},
+					{RelativeFrets: []int{10, 12, 15}},
+					{RelativeFrets: []int{12, 14, 17}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       4,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{6, 4, 2, 1},
+				StartOffset: 7,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 3, 5}},
+					{RelativeFrets: []int{3, 5, 7}},
+					{RelativeFrets: []int{5, 7, 10}},
+					{RelativeFrets: []int{7, 9, 12}},
+					{RelativeFrets: []int{10, 13, 15}},
+					{RelativeFrets: []int{12, 15, 17}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       5,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{6, 4, 3, 1},
 				StartOffset: 10,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{0, 2, 5}},
+					{RelativeFrets: []int{2, 4, 7}},
+					{RelativeFrets: []int{4, 7, 9}},
+					{RelativeFrets: []int{6, 9, 11}},
+					{RelativeFrets: []int{10, 12, 14}},
+					{RelativeFrets: []int{12, 14, 17}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 3}, {1, 3}, {1, 3}, {1, 2, 4}, {1, 3},
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
 				},
 			},
 		},
-		ThreeNPS: []Position{},
 	},
 	"major_pentatonic": {
 		ScaleName: "Major Pentatonic",
@@ -131,80 +222,80 @@ var AllScalePositions = map[string]ScalePositions{
 				Index:       1,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{1, 3, 6},
-				StartOffset: 0,
+				RootStrings: []int{6, 4, 1},
+				StartOffset: 11,
 				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
 					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 3}, {1, 3}, {1, 2, 4}, {1, 3}, {1, 3},
+					{1, 3}, {1, 3}, {1, 3}, {1, 2}, {1, 3}, {1, 3},
 				},
 			},
 			{
 				Index:       2,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{2, 4},
-				StartOffset: 2,
+				FretSpan:    5,
+				RootStrings: []int{4, 2},
+				StartOffset: 1,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{1, 4}},
+					{RelativeFrets: []int{1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 3}, {1, 2, 4}, {1, 3}, {1, 3}, {1, 3},
+					{1, 3}, {1, 3}, {1, 3}, {1, 3}, {1, 4}, {1, 3},
 				},
 			},
 			{
 				Index:       3,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{2, 5},
+				RootStrings: []int{5, 2},
 				StartOffset: 4,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
 					{RelativeFrets: []int{0, 2}},
 					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 2, 4}, {1, 3}, {1, 3}, {1, 3}, {1, 3},
+					{1, 4}, {1, 4}, {1, 3}, {1, 3}, {2, 4}, {1, 4},
 				},
 			},
 			{
 				Index:       4,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{3, 5},
-				StartOffset: 5,
+				RootStrings: []int{5, 3},
+				StartOffset: 6,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2}},
-					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3}, {1, 3}, {1, 3}, {1, 2, 4}, {1, 3}, {1, 3},
+					{1, 3}, {1, 3}, {1, 3}, {1, 3}, {1, 3}, {1, 3},
 				},
 			},
 			{
 				Index:       5,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{1, 4, 6},
-				StartOffset: 7,
+				RootStrings: []int{6, 3, 1},
+				StartOffset: 9,
 				NotePatterns: [6]NotePattern{
 					{RelativeFrets: []int{0, 3}},
 					{RelativeFrets: []int{0, 2}},
@@ -218,7 +309,98 @@ var AllScalePositions = map[string]ScalePositions{
 				},
 			},
 		},
-		ThreeNPS: []Position{},
+		ThreeNPS: []Position{
+			{
+				Index:       1,
+				Type:        PositionType3NPS,
+				FretSpan:    17,
+				RootStrings: []int{6, 5, 3, 1},
+				StartOffset: 0,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{2, 4, 7}},
+					{RelativeFrets: []int{4, 6, 9}},
+					{RelativeFrets: []int{6, 9, 11}},
+					{RelativeFrets: []int{9, 12, 14}},
+					{RelativeFrets: []int{12, 14, 16}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       2,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{5, 3, 2},
+				StartOffset: 2,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 5}},
+					{RelativeFrets: []int{2, 5, 7}},
+					{RelativeFrets: []int{4, 7, 9}},
+					{RelativeFrets: []int{7, 9, 11}},
+					{RelativeFrets: []int{10, 12, 15}},
+					{RelativeFrets: []int{12, 14, 17}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       3,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{5, 4, 2},
+				StartOffset: 4,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 3, 5}},
+					{RelativeFrets: []int{3, 5, 7}},
+					{RelativeFrets: []int{5, 7, 10}},
+					{RelativeFrets: []int{7, 9, 12}},
+					{RelativeFrets: []int{10, 13, 15}},
+					{RelativeFrets: []int{12, 15, 17}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       4,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{6, 4, 2, 1},
+				StartOffset: 7,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 5}},
+					{RelativeFrets: []int{2, 4, 7}},
+					{RelativeFrets: []int{4, 7, 9}},
+					{RelativeFrets: []int{6, 9, 11}},
+					{RelativeFrets: []int{10, 12, 14}},
+					{RelativeFrets: []int{12, 14, 17}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       5,
+				Type:        PositionType3NPS,
+				FretSpan:    18,
+				RootStrings: []int{6, 4, 3, 1},
+				StartOffset: 9,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 3, 5}},
+					{RelativeFrets: []int{2, 5, 7}},
+					{RelativeFrets: []int{5, 7, 9}},
+					{RelativeFrets: []int{7, 9, 12}},
+					{RelativeFrets: []int{10, 12, 15}},
+					{RelativeFrets: []int{12, 15, 17}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+		},
 	},
 	"blues": {
 		ScaleName: "Blues",
@@ -230,91 +412,200 @@ var AllScalePositions = map[string]ScalePositions{
 				RootStrings: []int{6, 4, 1},
 				StartOffset: 0,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
+					{RelativeFrets: []int{0, 3}},
 					{RelativeFrets: []int{0, 1, 2}},
+					{RelativeFrets: []int{0, 2}},
 					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 3, 4}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 3, 4},
+					{1, 4}, {1, 2, 3}, {1, 3}, {1, 3, 4}, {1, 4}, {1, 4},
 				},
 			},
 			{
 				Index:       2,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{2, 4},
-				StartOffset: 3,
+				FretSpan:    5,
+				RootStrings: []int{4, 2},
+				StartOffset: 2,
 				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
 					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2, 3}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3, 4}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3},
+					{1, 3, 4}, {1, 3}, {1, 3}, {1, 2, 3}, {1, 3}, {1, 3, 4},
 				},
 			},
 			{
 				Index:       3,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{2, 5},
-				StartOffset: 5,
+				FretSpan:    5,
+				RootStrings: []int{5, 2},
+				StartOffset: 4,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2, 3}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
+					{RelativeFrets: []int{1, 2, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{1, 4}},
+					{RelativeFrets: []int{1, 2, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3}, {1, 2, 3, 4}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3},
+					{1, 2, 3}, {1, 3}, {1, 3, 4}, {1, 3}, {1, 4}, {1, 2, 3},
 				},
 			},
 			{
 				Index:       4,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{3, 5},
-				StartOffset: 7,
+				FretSpan:    5,
+				RootStrings: []int{5, 3},
+				StartOffset: 6,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2, 3}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
+					{RelativeFrets: []int{1, 4}},
+					{RelativeFrets: []int{1, 4}},
+					{RelativeFrets: []int{1, 2, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{2, 4}},
+					{RelativeFrets: []int{0, 1, 4}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3, 4}, {1, 2, 3}, {1, 2, 3},
+					{1, 4}, {1, 4}, {1, 2, 3}, {1, 3}, {2, 4}, {1, 2, 4},
 				},
 			},
 			{
 				Index:       5,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{1, 3, 6},
-				StartOffset: 10,
+				FretSpan:    5,
+				RootStrings: []int{6, 3, 1},
+				StartOffset: 9,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{0, 3}},
+					{RelativeFrets: []int{1, 2, 3}},
+					{RelativeFrets: []int{1, 3}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3}, {1, 3, 4}, {1, 3}, {1, 3}, {1, 2, 3}, {1, 3},
+				},
+			},
+		},
+		ThreeNPS: []Position{
+			{
+				Index:       1,
+				Type:        PositionType3NPS,
+				FretSpan:    11,
+				RootStrings: []int{6, 4, 2},
+				StartOffset: 0,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 3, 5}},
+					{RelativeFrets: []int{1, 2, 5}},
+					{RelativeFrets: []int{2, 5, 7}},
+					{RelativeFrets: []int{3, 4, 7}},
+					{RelativeFrets: []int{5, 8, 10}},
+					{RelativeFrets: []int{6, 7, 10}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 2, 4}, {1, 3, 4}, {1, 2, 4}, {1, 3, 4}, {1, 2, 4},
+				},
+			},
+			{
+				Index:       2,
+				Type:        PositionType3NPS,
+				FretSpan:    11,
+				RootStrings: []int{5, 3, 1},
+				StartOffset: 2,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{0, 3, 5}},
+					{RelativeFrets: []int{3, 5, 6}},
+					{RelativeFrets: []int{2, 5, 7}},
+					{RelativeFrets: []int{6, 8, 9}},
+					{RelativeFrets: []int{5, 8, 10}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       3,
+				Type:        PositionType3NPS,
+				FretSpan:    11,
+				RootStrings: []int{5, 3, 1},
+				StartOffset: 5,
 				NotePatterns: [6]NotePattern{
 					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2}},
-					{RelativeFrets: []int{0, 1, 2, 3}},
-					{RelativeFrets: []int{0, 1, 2}},
+					{RelativeFrets: []int{0, 2, 5}},
+					{RelativeFrets: []int{2, 3, 4}},
+					{RelativeFrets: []int{2, 4, 7}},
+					{RelativeFrets: []int{5, 6, 7}},
+					{RelativeFrets: []int{5, 7, 10}},
+				},
+				FingerPattern: [6][]int{
+					{1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       4,
+				Type:        PositionType3NPS,
+				FretSpan:    12,
+				RootStrings: []int{5, 3, 1},
+				StartOffset: 6,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 1, 4}},
+					{RelativeFrets: []int{1, 4, 6}},
+					{RelativeFrets: []int{2, 3, 6}},
+					{RelativeFrets: []int{3, 6, 8}},
+					{RelativeFrets: []int{5, 6, 9}},
+					{RelativeFrets: []int{6, 9, 11}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3, 4}, {1, 2, 3},
+					{1, 2, 4}, {1, 3, 4}, {1, 2, 4}, {1, 3, 4}, {1, 2, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       5,
+				Type:        PositionType3NPS,
+				FretSpan:    12,
+				RootStrings: []int{6, 4, 2},
+				StartOffset: 7,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 3, 5}},
+					{RelativeFrets: []int{3, 5, 6}},
+					{RelativeFrets: []int{2, 5, 7}},
+					{RelativeFrets: []int{5, 7, 8}},
+					{RelativeFrets: []int{5, 8, 10}},
+					{RelativeFrets: []int{8, 10, 11}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       6,
+				Type:        PositionType3NPS,
+				FretSpan:    11,
+				RootStrings: []int{6, 4, 2},
+				StartOffset: 10,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 5}},
+					{RelativeFrets: []int{2, 3, 4}},
+					{RelativeFrets: []int{2, 4, 7}},
+					{RelativeFrets: []int{4, 5, 6}},
+					{RelativeFrets: []int{5, 7, 10}},
+					{RelativeFrets: []int{7, 8, 9}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 3, 4}, {1, 2, 3},
 				},
 			},
 		},
-		ThreeNPS: []Position{},
 	},
 	"major": {
 		ScaleName: "Major",
@@ -322,95 +613,222 @@ var AllScalePositions = map[string]ScalePositions{
 			{
 				Index:       1,
 				Type:        PositionTypeCAGED,
-				FretSpan:    5,
+				FretSpan:    4,
 				RootStrings: []int{6, 4, 1},
-				StartOffset: 0,
+				StartOffset: 11,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{1, 3, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4},
+					{1, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3}, {1, 2, 3},
 				},
 			},
 			{
 				Index:       2,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{2, 4},
-				StartOffset: 2,
+				FretSpan:    5,
+				RootStrings: []int{4, 2},
+				StartOffset: 1,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2, 4}},
 					{RelativeFrets: []int{1, 3, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 3}},
 					{RelativeFrets: []int{0, 1, 3}},
-					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3, 4}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 3}, {1, 2, 4}, {1, 2, 4},
+					{1, 3, 4}, {1, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 3, 4},
 				},
 			},
 			{
 				Index:       3,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{2, 5},
+				RootStrings: []int{5, 2},
 				StartOffset: 4,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+				},
+				FingerPattern: [6][]int{
+					{1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3}, {1, 2, 4}, {1, 2, 4},
+				},
+			},
+			{
+				Index:       4,
+				Type:        PositionTypeCAGED,
+				FretSpan:    5,
+				RootStrings: []int{5, 3},
+				StartOffset: 6,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 3},
+				},
+			},
+			{
+				Index:       5,
+				Type:        PositionTypeCAGED,
+				FretSpan:    5,
+				RootStrings: []int{6, 3, 1},
+				StartOffset: 8,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{1, 3, 4}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3}, {1, 2, 3}, {1, 2, 4}, {1, 3, 4},
+				},
+			},
+		},
+		ThreeNPS: []Position{
+			{
+				Index:       1,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{6, 4, 2},
+				StartOffset: 0,
 				NotePatterns: [6]NotePattern{
 					{RelativeFrets: []int{0, 2, 4}},
 					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{2, 4, 5}},
+					{RelativeFrets: []int{2, 4, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       2,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{4, 2},
+				StartOffset: 2,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{0, 2, 4}},
 					{RelativeFrets: []int{0, 2, 4}},
 					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{2, 3, 5}},
+					{RelativeFrets: []int{2, 3, 5}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 2, 4},
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4},
+				},
+			},
+			{
+				Index:       3,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{5, 2},
+				StartOffset: 4,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 3, 5}},
+					{RelativeFrets: []int{1, 3, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
 				},
 			},
 			{
 				Index:       4,
-				Type:        PositionTypeCAGED,
-				FretSpan:    5,
-				RootStrings: []int{3, 5},
+				Type:        PositionType3NPS,
+				FretSpan:    7,
+				RootStrings: []int{5, 3},
 				StartOffset: 5,
 				NotePatterns: [6]NotePattern{
 					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
 					{RelativeFrets: []int{1, 3, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{2, 4, 5}},
+					{RelativeFrets: []int{2, 4, 6}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4},
+					{1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
 				},
 			},
 			{
 				Index:       5,
-				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{1, 3, 6},
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{5, 3, 1},
 				StartOffset: 7,
 				NotePatterns: [6]NotePattern{
 					{RelativeFrets: []int{0, 2, 4}},
 					{RelativeFrets: []int{0, 2, 4}},
 					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{2, 3, 5}},
+					{RelativeFrets: []int{2, 4, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       6,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{6, 3, 1},
+				StartOffset: 9,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{0, 2, 4}},
-					{RelativeFrets: []int{1, 3, 4}},
 					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 3, 5}},
+					{RelativeFrets: []int{2, 3, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 2, 4},
+				},
+			},
+			{
+				Index:       7,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{6, 4, 1},
+				StartOffset: 11,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3, 5}},
+					{RelativeFrets: []int{1, 3, 5}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 4}, {1, 2, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 2, 4},
+					{1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
 				},
 			},
 		},
-		ThreeNPS: []Position{},
 	},
 	"minor": {
 		ScaleName: "Minor",
@@ -420,93 +838,220 @@ var AllScalePositions = map[string]ScalePositions{
 				Type:        PositionTypeCAGED,
 				FretSpan:    5,
 				RootStrings: []int{6, 4, 1},
-				StartOffset: 0,
+				StartOffset: 11,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{1, 3, 4}},
-					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{1, 2, 4}},
 					{RelativeFrets: []int{1, 3, 4}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4},
+					{1, 3, 4}, {1, 3, 4}, {1, 3}, {1, 2, 3}, {1, 2, 4}, {1, 3, 4},
 				},
 			},
 			{
 				Index:       2,
 				Type:        PositionTypeCAGED,
 				FretSpan:    4,
-				RootStrings: []int{2, 4},
+				RootStrings: []int{4, 2},
 				StartOffset: 2,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{1, 3, 4}},
-					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3}},
 					{RelativeFrets: []int{0, 1, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3},
+					{1, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3}, {1, 2, 3},
 				},
 			},
 			{
 				Index:       3,
 				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{2, 5},
+				FretSpan:    5,
+				RootStrings: []int{5, 2},
 				StartOffset: 4,
 				NotePatterns: [6]NotePattern{
-					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3, 4}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       4,
+				Type:        PositionTypeCAGED,
+				FretSpan:    4,
+				RootStrings: []int{5, 3},
+				StartOffset: 7,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 1, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3},
+					{1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3}, {1, 2, 4}, {1, 2, 4},
 				},
 			},
 			{
-				Index:       4,
+				Index:       5,
 				Type:        PositionTypeCAGED,
 				FretSpan:    5,
-				RootStrings: []int{3, 5},
-				StartOffset: 5,
+				RootStrings: []int{6, 3, 1},
+				StartOffset: 9,
 				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{1, 3}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 3},
+				},
+			},
+		},
+		ThreeNPS: []Position{
+			{
+				Index:       1,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{6, 4, 2},
+				StartOffset: 0,
+				NotePatterns: [6]NotePattern{
 					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{1, 3, 4}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 3, 5}},
+					{RelativeFrets: []int{2, 3, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 2, 4},
+				},
+			},
+			{
+				Index:       2,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{4, 2},
+				StartOffset: 2,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 1, 3}},
+					{RelativeFrets: []int{0, 1, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{1, 3, 5}},
+					{RelativeFrets: []int{1, 3, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       3,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{5, 2},
+				StartOffset: 3,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{2, 4, 5}},
+					{RelativeFrets: []int{2, 4, 5}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 2, 3}, {1, 2, 3},
+					{1, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       4,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{5, 3},
+				StartOffset: 5,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{2, 3, 5}},
+					{RelativeFrets: []int{2, 3, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4},
 				},
 			},
 			{
 				Index:       5,
-				Type:        PositionTypeCAGED,
-				FretSpan:    4,
-				RootStrings: []int{1, 3, 6},
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{5, 3, 1},
 				StartOffset: 7,
 				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 1, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
 					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 2, 3}},
-					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 3, 5}},
+					{RelativeFrets: []int{1, 3, 5}},
+				},
+				FingerPattern: [6][]int{
+					{1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       6,
+				Type:        PositionType3NPS,
+				FretSpan:    7,
+				RootStrings: []int{6, 3, 1},
+				StartOffset: 8,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
 					{RelativeFrets: []int{1, 3, 4}},
-					{RelativeFrets: []int{0, 2, 3}},
+					{RelativeFrets: []int{2, 4, 5}},
+					{RelativeFrets: []int{2, 4, 6}},
+				},
+				FingerPattern: [6][]int{
+					{1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4}, {1, 3, 4}, {1, 3, 4},
+				},
+			},
+			{
+				Index:       7,
+				Type:        PositionType3NPS,
+				FretSpan:    6,
+				RootStrings: []int{6, 4, 1},
+				StartOffset: 10,
+				NotePatterns: [6]NotePattern{
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{0, 2, 4}},
+					{RelativeFrets: []int{1, 2, 4}},
+					{RelativeFrets: []int{2, 3, 5}},
+					{RelativeFrets: []int{2, 4, 5}},
 				},
 				FingerPattern: [6][]int{
-					{1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 2, 3}, {1, 3, 4}, {1, 2, 3},
+					{1, 3, 4}, {1, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 4}, {1, 3, 4},
 				},
 			},
 		},
-		ThreeNPS: []Position{},
 	},
 }
 
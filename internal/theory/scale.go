@@ -1,5 +1,7 @@
 package theory
 
+import "strings"
+
 // ScaleFormula lưu khoảng cách các nốt (intervals)
 type ScaleFormula []int
 
@@ -38,3 +40,40 @@ func IsNoteInScale(target, root Note, scaleName string) bool {
 	}
 	return false
 }
+
+// ScaleOrder là thứ tự duyệt các scale trong UI (map không có thứ tự)
+var ScaleOrder = []string{
+	"major", "minor", "major_pentatonic", "minor_pentatonic", "blues",
+	"dorian", "phrygian", "lydian", "mixolydian", "locrian",
+	"harmonic_minor", "melodic_minor", "whole_tone", "diminished_hw", "diminished_wh", "chromatic",
+}
+
+// ScaleDisplayName turns a scale key into a title: "minor_pentatonic" -> "Minor Pentatonic"
+func ScaleDisplayName(scaleName string) string {
+	words := strings.Split(scaleName, "_")
+	for i, w := range words {
+		switch {
+		case w == "hw" || w == "wh":
+			words[i] = "(" + strings.ToUpper(w) + ")"
+		case w != "":
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// ScaleKey returns the key whose spelling fits a scale on root:
+// scales with a minor third are spelled like the minor key
+func ScaleKey(root Note, scaleName string) Key {
+	minor := false
+	for _, interval := range Scales[scaleName] {
+		if interval == 4 {
+			minor = false
+			break
+		}
+		if interval == 3 {
+			minor = true
+		}
+	}
+	return DefaultKey(root, minor)
+}
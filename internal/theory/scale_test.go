@@ -0,0 +1,50 @@
+package theory
+
+import "testing"
+
+func TestScaleOrder(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, name := range ScaleOrder {
+		if _, ok := Scales[name]; !ok {
+			t.Errorf("%s is listed but not defined", name)
+		}
+		if seen[name] {
+			t.Errorf("%s is listed twice", name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestScaleDisplayName(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"major", "Major"},
+		{"minor_pentatonic", "Minor Pentatonic"},
+		{"diminished_hw", "Diminished (HW)"},
+	}
+	for _, tt := range tests {
+		if got := ScaleDisplayName(tt.name); got != tt.want {
+			t.Errorf("ScaleDisplayName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScaleKey(t *testing.T) {
+	tests := []struct {
+		root  Note
+		scale string
+		want  string
+	}{
+		{F, "major", "F"},
+		{D, "minor", "Dm"},
+		{As, "minor_pentatonic", "Bbm"},
+		{A, "blues", "Am"},
+		{G, "mixolydian", "G"},
+		{E, "dorian", "Em"},
+		{C, "whole_tone", "C"}, // No third: major spelling
+	}
+	for _, tt := range tests {
+		if got := ScaleKey(tt.root, tt.scale).String(); got != tt.want {
+			t.Errorf("ScaleKey(%s, %s) = %s, want %s", NoteNames[tt.root], tt.scale, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package theory
+
+// SequenceNote is one note of a scale sequence
+type SequenceNote struct {
+	String int // 0 = low E
+	Fret   int
+	Finger int // From the position's FingerPattern, 0 if it has none
+}
+
+// SequencePattern is a melodic sequence over the notes of a scale position.
+// Group patterns play Group notes from every scale note in turn (1-2-3,
+// 2-3-4...); interval patterns pair every note with the one Skip scale
+// steps above (thirds: 1-3, 2-4...).
+type SequencePattern struct {
+	Name  string
+	Group int
+	Skip  int
+}
+
+// SequencePatterns lists the sequences in menu order
+var SequencePatterns = []SequencePattern{
+	{Name: "Groups of 3", Group: 3},
+	{Name: "Groups of 4", Group: 4},
+	{Name: "Groups of 6", Group: 6},
+	{Name: "Thirds", Skip: 2},
+	{Name: "Fourths", Skip: 3},
+	{Name: "Sixths", Skip: 5},
+}
+
+// Subdivision is the natural phrasing of the pattern in notes per beat:
+// triplets for groups of 3 and 6, sixteenths otherwise
+func (p SequencePattern) Subdivision() int {
+	if p.Group%3 == 0 && p.Group > 0 {
+		return 3
+	}
+	return 4
+}
+
+// Apply runs the pattern over notes, which are in ascending order.
+// Descending sequences run it over the notes from the top down.
+func (p SequencePattern) Apply(notes []SequenceNote, descending bool) []SequenceNote {
+	if descending {
+		reversed := make([]SequenceNote, len(notes))
+		for i, n := range notes {
+			reversed[len(notes)-1-i] = n
+		}
+		notes = reversed
+	}
+
+	var seq []SequenceNote
+	switch {
+	case p.Group > 0:
+		for i := 0; i+p.Group <= len(notes); i++ {
+			seq = append(seq, notes[i:i+p.Group]...)
+		}
+	case p.Skip > 0:
+		for i := 0; i+p.Skip < len(notes); i++ {
+			seq = append(seq, notes[i], notes[i+p.Skip])
+		}
+	}
+	return seq
+}
+
+// PositionNotes lists the notes of a position from the lowest string up,
+// with the position's fingering, for a scale rooted on root. The position
+// is placed at the lowest fret above the nut where it fits.
+func PositionNotes(pos Position, root Note, tuning []Note) []SequenceNote {
+	rootFret := (int(root) - int(tuning[0]) + 12) % 12
+	start, _ := CalculateFretRange(pos, rootFret)
+	for start > 12 {
+		start -= 12
+		rootFret -= 12
+	}
+	if start < 1 {
+		rootFret += 12
+	}
+
+	var notes []SequenceNote
+	for s, pattern := range pos.NotePatterns {
+		if s >= len(tuning) {
+			break
+		}
+		for k, rel := range pattern.RelativeFrets {
+			n := SequenceNote{String: s, Fret: rootFret + pos.StartOffset + rel}
+			if k < len(pos.FingerPattern[s]) {
+				n.Finger = pos.FingerPattern[s][k]
+			}
+			notes = append(notes, n)
+		}
+	}
+	return notes
+}
@@ -0,0 +1,78 @@
+package theory
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSequenceApply(t *testing.T) {
+	// Five scale notes, told apart by their fret
+	var notes []SequenceNote
+	for f := 1; f <= 5; f++ {
+		notes = append(notes, SequenceNote{Fret: f})
+	}
+	tests := []struct {
+		pattern    SequencePattern
+		descending bool
+		want       []int
+	}{
+		{SequencePatterns[0], false, []int{1, 2, 3, 2, 3, 4, 3, 4, 5}},
+		{SequencePatterns[0], true, []int{5, 4, 3, 4, 3, 2, 3, 2, 1}},
+		{SequencePatterns[1], false, []int{1, 2, 3, 4, 2, 3, 4, 5}},
+		{SequencePatterns[2], false, nil}, // Fewer notes than a group
+		{SequencePatterns[3], false, []int{1, 3, 2, 4, 3, 5}},
+		{SequencePatterns[4], true, []int{5, 2, 4, 1}},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, n := range tt.pattern.Apply(notes, tt.descending) {
+			got = append(got, n.Fret)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s (descending %v) = %v, want %v", tt.pattern.Name, tt.descending, got, tt.want)
+		}
+	}
+
+	subdivisions := map[string]int{"Groups of 3": 3, "Groups of 4": 4, "Groups of 6": 3, "Thirds": 4}
+	for _, p := range SequencePatterns {
+		if want, ok := subdivisions[p.Name]; ok && p.Subdivision() != want {
+			t.Errorf("%s: %d notes per beat, want %d", p.Name, p.Subdivision(), want)
+		}
+	}
+}
+
+func TestPositionNotes(t *testing.T) {
+	tests := []struct {
+		scale string
+		root  Note
+		index int
+		first string // String (1 = high e) and fret of the first and last notes
+		last  string
+		count int
+	}{
+		{"minor_pentatonic", A, 1, "6:5", "1:8", 12},
+		{"minor_pentatonic", E, 1, "6:12", "1:15", 12}, // Above the nut, not on it
+		{"major", G, 1, "6:3", "1:5", 16},
+	}
+	for _, tt := range tests {
+		pos, ok := GetPosition(tt.scale, PositionTypeCAGED, tt.index)
+		if !ok {
+			t.Fatalf("no %s position %d", tt.scale, tt.index)
+		}
+		notes := PositionNotes(pos, tt.root, StandardTuning)
+		for i, n := range notes {
+			if !IsNoteInScale(CalculateNote(StandardTuning[n.String], n.Fret), tt.root, tt.scale) {
+				t.Errorf("%s %s: string %d fret %d is not in the scale", NoteNames[tt.root], tt.scale, 6-n.String, n.Fret)
+			}
+			if i > 0 && n.String < notes[i-1].String || n.Finger < 1 || n.Finger > 4 {
+				t.Errorf("%s %s note %d: string %d finger %d", NoteNames[tt.root], tt.scale, i+1, 6-n.String, n.Finger)
+			}
+		}
+		first, last := notes[0], notes[len(notes)-1]
+		if got := fmt.Sprintf("%d:%d", 6-first.String, first.Fret); got != tt.first || len(notes) != tt.count ||
+			fmt.Sprintf("%d:%d", 6-last.String, last.Fret) != tt.last {
+			t.Errorf("%s %s: %d notes from %s to %d:%d", NoteNames[tt.root], tt.scale, len(notes), got, 6-last.String, last.Fret)
+		}
+	}
+}
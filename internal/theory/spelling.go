@@ -0,0 +1,228 @@
+package theory
+
+import (
+	"strings"
+)
+
+// Letters là 7 tên nốt tự nhiên, theo thứ tự
+var Letters = []string{"C", "D", "E", "F", "G", "A", "B"}
+
+// letterNotes là cao độ của nốt tự nhiên ứng với từng chữ cái
+var letterNotes = []Note{C, D, E, F, G, A, B}
+
+// SpelledNote is a note with its letter: D# and Eb are the same Note but
+// different SpelledNotes
+type SpelledNote struct {
+	Letter     int // 0-6 = C D E F G A B
+	Accidental int // -2 = bb, -1 = b, 0, 1 = #, 2 = ##
+}
+
+// Note returns the pitch class
+func (s SpelledNote) Note() Note {
+	return Note(((int(letterNotes[s.Letter])+s.Accidental)%12 + 12) % 12)
+}
+
+// String formats the note: "C", "Bb", "F#", "C##", "Ebb"
+func (s SpelledNote) String() string {
+	acc := ""
+	switch {
+	case s.Accidental > 0:
+		acc = strings.Repeat("#", s.Accidental)
+	case s.Accidental < 0:
+		acc = strings.Repeat("b", -s.Accidental)
+	}
+	return Letters[s.Letter] + acc
+}
+
+// spellWithLetter spells note n using the given letter, picking the accidental
+func spellWithLetter(n Note, letter int) SpelledNote {
+	acc := (int(n) - int(letterNotes[letter]) + 12) % 12
+	if acc > 6 {
+		acc -= 12
+	}
+	return SpelledNote{Letter: letter, Accidental: acc}
+}
+
+// ParseSpelledNote reads a note name at the start of s: "Bb", "F#", "C##",
+// "Ebb", "D♭", "G♯". Returns the note and the rest of the string.
+func ParseSpelledNote(s string) (SpelledNote, string, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return SpelledNote{}, s, false
+	}
+	letter := strings.Index("CDEFGAB", strings.ToUpper(s[:1]))
+	if letter == -1 {
+		return SpelledNote{}, s, false
+	}
+
+	sn := SpelledNote{Letter: letter}
+	rest := s[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "#"):
+			sn.Accidental++
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "♯"):
+			sn.Accidental++
+			rest = rest[len("♯"):]
+		case strings.HasPrefix(rest, "b"):
+			sn.Accidental--
+			rest = rest[1:]
+		case strings.HasPrefix(rest, "♭"):
+			sn.Accidental--
+			rest = rest[len("♭"):]
+		default:
+			return sn, rest, true
+		}
+	}
+	return sn, rest, true
+}
+
+// ParseNote reads a note name with sharps or flats ("Bb", "A#", "Cb", "E#")
+func ParseNote(s string) (Note, bool) {
+	sn, rest, ok := ParseSpelledNote(s)
+	if !ok || strings.TrimSpace(rest) != "" {
+		return C, false
+	}
+	return sn.Note(), true
+}
+
+// Key is a tonic with its spelling and quality; it decides how notes are named
+type Key struct {
+	Tonic SpelledNote
+	Minor bool
+}
+
+// Conventional spellings of the 12 major and minor keys (fewest accidentals,
+// matching the circle of fifths: Db major, Bbm, F# major, D#m)
+var (
+	majorKeyLetters = [12]string{"C", "Db", "D", "Eb", "E", "F", "F#", "G", "Ab", "A", "Bb", "B"}
+	minorKeyLetters = [12]string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "Bb", "B"}
+)
+
+// DefaultKey returns the usual spelling of the key on tonic n
+func DefaultKey(n Note, minor bool) Key {
+	name := majorKeyLetters[n]
+	if minor {
+		name = minorKeyLetters[n]
+	}
+	tonic, _, _ := ParseSpelledNote(name)
+	return Key{Tonic: tonic, Minor: minor}
+}
+
+// ParseKey reads a key header: "A", "Bb", "F#m", "Ebmin", "C major", "G# minor"
+func ParseKey(s string) (Key, bool) {
+	tonic, rest, ok := ParseSpelledNote(s)
+	if !ok {
+		return Key{}, false
+	}
+	quality := strings.ToLower(strings.TrimSpace(rest))
+	minor := strings.HasPrefix(quality, "m") && !strings.HasPrefix(quality, "maj")
+	return Key{Tonic: tonic, Minor: minor}, true
+}
+
+// String formats the key the way KEY: headers write it: "Bb", "F#m"
+func (k Key) String() string {
+	if k.Minor {
+		return k.Tonic.String() + "m"
+	}
+	return k.Tonic.String()
+}
+
+// Root returns the tonic pitch class
+func (k Key) Root() Note {
+	return k.Tonic.Note()
+}
+
+// Signature returns the key signature: number of sharps (> 0) or flats (< 0)
+func (k Key) Signature() int {
+	scale := "major"
+	if k.Minor {
+		scale = "minor"
+	}
+	sig := 0
+	for _, n := range SpellScale(k.Tonic, Scales[scale]) {
+		sig += n.Accidental
+	}
+	return sig
+}
+
+// Scale spells the key's diatonic scale (natural minor for minor keys)
+func (k Key) Scale() []SpelledNote {
+	if k.Minor {
+		return SpellScale(k.Tonic, Scales["minor"])
+	}
+	return SpellScale(k.Tonic, Scales["major"])
+}
+
+// SpellNote names n in the key: scale notes with the key's letters (the
+// raised 6th and 7th of minor keys too, so G# harmonic minor has F##),
+// other notes with sharps in sharp keys and flats in flat keys
+func (k Key) SpellNote(n Note) SpelledNote {
+	scales := [][]SpelledNote{k.Scale()}
+	if k.Minor {
+		scales = append(scales, SpellScale(k.Tonic, Scales["melodic_minor"]))
+	}
+	for _, scale := range scales {
+		for _, sn := range scale {
+			if sn.Note() == n {
+				return sn
+			}
+		}
+	}
+
+	// Chromatic note: nearest natural letter with a single accidental
+	if k.Signature() < 0 || (k.Signature() == 0 && k.Minor) {
+		return spellWithLetter(n, flatLetter(n))
+	}
+	return spellWithLetter(n, sharpLetter(n))
+}
+
+// Spell names n in the key
+func (k Key) Spell(n Note) string {
+	return k.SpellNote(n).String()
+}
+
+// sharpLetter is the letter of n written as natural or sharp
+func sharpLetter(n Note) int {
+	return nearestLetter(n, 1)
+}
+
+// flatLetter is the letter of n written as natural or flat
+func flatLetter(n Note) int {
+	return nearestLetter(n, -1)
+}
+
+// nearestLetter returns the natural letter of n, or the letter one
+// accidental (+1 sharp, -1 flat) away
+func nearestLetter(n Note, accidental int) int {
+	for _, acc := range []int{0, accidental} {
+		for l, natural := range letterNotes {
+			if (int(natural)+acc+12)%12 == int(n) {
+				return l
+			}
+		}
+	}
+	return 0
+}
+
+// degreeOfInterval is the scale degree (0-based letter step) used for an
+// interval in scales that don't have seven notes: 3 = b3, 6 = b5, 10 = b7
+var degreeOfInterval = [12]int{0, 1, 1, 2, 2, 3, 4, 4, 5, 5, 6, 6}
+
+// SpellScale spells a scale from tonic. Seven-note scales use every letter
+// once, so intervals get the right degree letters (E# in C# major, F## in
+// G# harmonic minor); other scales name each interval by its usual degree.
+func SpellScale(tonic SpelledNote, formula ScaleFormula) []SpelledNote {
+	root := tonic.Note()
+	spelled := make([]SpelledNote, len(formula))
+	for i, interval := range formula {
+		degree := degreeOfInterval[interval%12]
+		if len(formula) == 7 {
+			degree = i
+		}
+		n := Note((int(root) + interval) % 12)
+		spelled[i] = spellWithLetter(n, (tonic.Letter+degree)%7)
+	}
+	return spelled
+}
@@ -0,0 +1,79 @@
+package theory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpellScale(t *testing.T) {
+	tests := []struct {
+		tonic string
+		scale string
+		want  string
+	}{
+		{"C#", "major", "C# D# E# F# G# A# B#"},
+		{"Gb", "major", "Gb Ab Bb Cb Db Eb F"},
+		{"G#", "harmonic_minor", "G# A# B C# D# E F##"},
+		{"Bb", "minor", "Bb C Db Eb F Gb Ab"},
+		{"A", "minor_pentatonic", "A C D E G"},
+		{"Eb", "blues", "Eb Gb Ab Bbb Bb Db"},
+	}
+	for _, tt := range tests {
+		tonic, _, ok := ParseSpelledNote(tt.tonic)
+		if !ok {
+			t.Fatalf("cannot parse %q", tt.tonic)
+		}
+		var names []string
+		for _, sn := range SpellScale(tonic, Scales[tt.scale]) {
+			names = append(names, sn.String())
+		}
+		if got := strings.Join(names, " "); got != tt.want {
+			t.Errorf("%s %s = %s, want %s", tt.tonic, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestKeySpell(t *testing.T) {
+	tests := []struct {
+		key  string
+		note Note
+		want string
+	}{
+		{"F", As, "Bb"},
+		{"Bb", Ds, "Eb"},
+		{"E", Ds, "D#"},
+		{"C", Cs, "C#"},  // Chromatic in C: sharp
+		{"Dm", As, "Bb"}, // Scale note of D minor
+		{"Dm", Cs, "C#"}, // Raised 7th
+		{"Am", Ds, "Eb"}, // Chromatic in a minor key: flat
+		{"F#", F, "E#"},
+	}
+	for _, tt := range tests {
+		k, ok := ParseKey(tt.key)
+		if !ok {
+			t.Fatalf("cannot parse key %q", tt.key)
+		}
+		if got := k.Spell(tt.note); got != tt.want {
+			t.Errorf("%s in %s = %s, want %s", NoteNames[tt.note], tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestPitchSpell(t *testing.T) {
+	tests := []struct {
+		pitch Pitch
+		key   string
+		want  string
+	}{
+		{NewPitch(As, 3), "F", "Bb3"},
+		{NewPitch(As, 3), "B", "A#3"},
+		{NewPitch(C, 4), "C#", "B#3"}, // The octave follows the letter
+		{NewPitch(B, 3), "Gb", "Cb4"},
+	}
+	for _, tt := range tests {
+		k, _ := ParseKey(tt.key)
+		if got := tt.pitch.Spell(k); got != tt.want {
+			t.Errorf("pitch %d in %s = %s, want %s", tt.pitch, tt.key, got, tt.want)
+		}
+	}
+}
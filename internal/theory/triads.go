@@ -0,0 +1,98 @@
+package theory
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TriadGroup is every shape of a triad on one string set, grouped by
+// inversion (root position, 1st, 2nd), each group up the neck
+type TriadGroup struct {
+	Set        StringSet
+	Inversions [3][]Voicing
+}
+
+// Triad reports whether the quality is a major, minor, diminished or
+// augmented triad: three notes with a 3rd
+func (q ChordQuality) Triad() bool {
+	return len(q.Intervals) == 3 && (containsInterval(q.Intervals, 3) || containsInterval(q.Intervals, 4))
+}
+
+// TriadSets are the adjacent three-string sets of a tuning, low strings
+// first, named by string number like StringSets: 4-6, 3-5, 2-4, 1-3
+func TriadSets(strings int) []StringSet {
+	var sets []StringSet
+	for s := 0; s+3 <= strings; s++ {
+		sets = append(sets, StringSet{
+			Name:    fmt.Sprintf("%d-%d", strings-s-2, strings-s),
+			Strings: []int{s, s + 1, s + 2},
+		})
+	}
+	return sets
+}
+
+// FindTriads lists the shapes of a triad on every adjacent three-string
+// set of the tuning up to fretCount: one chord tone per string, all three
+// tones, fretted notes within DefaultVoicingSpan frets
+func FindTriads(root Note, quality ChordQuality, tuning []Note, fretCount int) []TriadGroup {
+	var chord [12]bool
+	for _, interval := range quality.Intervals {
+		chord[(int(root)+interval)%12] = true
+	}
+	// Chord-tone frets of every string
+	tones := make([][]int, len(tuning))
+	for s := range tuning {
+		for f := 0; f <= fretCount; f++ {
+			if chord[CalculateNote(tuning[s], f)] {
+				tones[s] = append(tones[s], f)
+			}
+		}
+	}
+
+	var groups []TriadGroup
+	frets := make([]int, len(tuning))
+	for _, set := range TriadSets(len(tuning)) {
+		group := TriadGroup{Set: set}
+		for i := range frets {
+			frets[i] = -1
+		}
+		a, b, c := set.Strings[0], set.Strings[1], set.Strings[2]
+		for _, fa := range tones[a] {
+			for _, fb := range tones[b] {
+				for _, fc := range tones[c] {
+					if !frettedWithin([]int{fa, fb, fc}, DefaultVoicingSpan) {
+						continue
+					}
+					frets[a], frets[b], frets[c] = fa, fb, fc
+					v, ok := newVoicing(root, quality, tuning, frets, DefaultVoicingSpan)
+					if !ok {
+						continue
+					}
+					if inversion := v.Inversion(); inversion >= 0 && inversion < 3 {
+						group.Inversions[inversion] = append(group.Inversions[inversion], v)
+					}
+				}
+			}
+		}
+		for _, shapes := range group.Inversions {
+			sort.SliceStable(shapes, func(i, j int) bool { return shapes[i].Position() < shapes[j].Position() })
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// frettedWithin reports whether the fretted (non-open) frets fit in span frets
+func frettedWithin(frets []int, span int) bool {
+	low, high := -1, -1
+	for _, f := range frets {
+		if f <= 0 {
+			continue
+		}
+		if low == -1 || f < low {
+			low = f
+		}
+		high = max(high, f)
+	}
+	return low == -1 || high-low < span
+}
@@ -0,0 +1,102 @@
+package theory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChordQualityTriad(t *testing.T) {
+	tests := []struct {
+		suffix string
+		want   bool
+	}{
+		{"", true},
+		{"m", true},
+		{"dim", true},
+		{"aug", true},
+		{"sus4", false}, // No 3rd
+		{"sus2", false},
+		{"7", false}, // Four notes
+	}
+	for _, tt := range tests {
+		if got := qualityBySuffix(t, tt.suffix).Triad(); got != tt.want {
+			t.Errorf("%q triad = %v, want %v", tt.suffix, got, tt.want)
+		}
+	}
+}
+
+func TestTriadSets(t *testing.T) {
+	tests := []struct {
+		strings int
+		want    string
+	}{
+		{6, "4-6 3-5 2-4 1-3"},
+		{7, "5-7 4-6 3-5 2-4 1-3"},
+		{4, "2-4 1-3"},
+		{2, ""},
+	}
+	for _, tt := range tests {
+		var names []string
+		for i, set := range TriadSets(tt.strings) {
+			names = append(names, set.Name)
+			if want := []int{i, i + 1, i + 2}; len(set.Strings) != 3 || set.Strings[0] != want[0] || set.Strings[2] != want[2] {
+				t.Errorf("%d strings, set %s: strings %v, want %v", tt.strings, set.Name, set.Strings, want)
+			}
+		}
+		if got := strings.Join(names, " "); got != tt.want {
+			t.Errorf("%d strings: sets %q, want %q", tt.strings, got, tt.want)
+		}
+	}
+}
+
+func TestFindTriads(t *testing.T) {
+	tests := []struct {
+		root   Note
+		suffix string
+		every  bool // Every inversion has a shape on every set
+	}{
+		{C, "", true},
+		{A, "m", true},
+		// Root position Bdim on 4-6 needs a five-fret stretch
+		{B, "dim", false},
+		{Fs, "", false},
+	}
+	for _, tt := range tests {
+		quality := qualityBySuffix(t, tt.suffix)
+		name := NoteNames[tt.root] + tt.suffix
+		groups := FindTriads(tt.root, quality, StandardTuning, 12)
+		if len(groups) != 4 {
+			t.Fatalf("%s: %d string sets, want 4", name, len(groups))
+		}
+		for _, group := range groups {
+			for inversion, shapes := range group.Inversions {
+				if tt.every && len(shapes) == 0 {
+					t.Errorf("%s on %s: no shapes for inversion %d", name, group.Set.Name, inversion)
+				}
+				for i, v := range shapes {
+					if !v.OnStrings(group.Set.Strings) {
+						t.Errorf("%s on %s: %s is not on the set", name, group.Set.Name, v)
+					}
+					if got := v.Inversion(); got != inversion {
+						t.Errorf("%s on %s: %s is inversion %d, grouped as %d", name, group.Set.Name, v, got, inversion)
+					}
+					if !frettedWithin(v.Frets, DefaultVoicingSpan) {
+						t.Errorf("%s on %s: %s spans more than %d frets", name, group.Set.Name, v, DefaultVoicingSpan)
+					}
+					var tones [12]bool
+					for _, p := range v.Pitches {
+						tones[p.Note()] = true
+					}
+					for _, interval := range quality.Intervals {
+						if !tones[(int(tt.root)+interval)%12] {
+							t.Errorf("%s on %s: %s misses a chord tone", name, group.Set.Name, v)
+						}
+					}
+					if i > 0 && shapes[i-1].Position() > v.Position() {
+						t.Errorf("%s on %s inversion %d: not sorted up the neck", name, group.Set.Name, inversion)
+					}
+				}
+			}
+		}
+	}
+}
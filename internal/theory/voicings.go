@@ -0,0 +1,301 @@
+package theory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultVoicingSpan is how many frets a chord shape may cover
+const DefaultVoicingSpan = 4
+
+// Voicing is one way to play a chord: a fret per string (low E first, -1 =
+// muted) with a suggested fingering (0 for open and muted strings)
+type Voicing struct {
+	Root    Note
+	Quality ChordQuality
+	Frets   []int
+	Fingers []int
+	Pitches []Pitch // Sounding pitches, low to high
+}
+
+// StringSet is a group of adjacent strings chords are voiced on
+type StringSet struct {
+	Name    string
+	Strings []int // String indexes (0 = low E); nil = any strings
+}
+
+// StringSets lists the usual sets, named by string number (1 = high e)
+var StringSets = []StringSet{
+	{"all", nil},
+	{"1-4", []int{2, 3, 4, 5}},
+	{"2-5", []int{1, 2, 3, 4}},
+	{"3-6", []int{0, 1, 2, 3}},
+	{"1-3", []int{3, 4, 5}},
+	{"2-4", []int{2, 3, 4}},
+	{"3-5", []int{1, 2, 3}},
+	{"4-6", []int{0, 1, 2}},
+}
+
+// FindVoicings lists the playable voicings of a chord up to fretCount:
+// every chord tone sounds (the 5th may be left out of 4+ note chords),
+// fretted notes fit in span frets (open strings only with shapes near the
+// nut), at most one inner string is muted and the fingering needs no more
+// than four fingers. Open shapes come first, then root position.
+func FindVoicings(root Note, quality ChordQuality, tuning []Note, fretCount, span int) []Voicing {
+	var chord [12]bool
+	for _, interval := range quality.Intervals {
+		chord[(int(root)+interval)%12] = true
+	}
+
+	var voicings []Voicing
+	frets := make([]int, len(tuning))
+	var walk func(s, low, high int)
+	walk = func(s, low, high int) {
+		if s == len(tuning) {
+			if v, ok := newVoicing(root, quality, tuning, frets, span); ok {
+				voicings = append(voicings, v)
+			}
+			return
+		}
+		frets[s] = -1
+		walk(s+1, low, high)
+		for f := 0; f <= fretCount; f++ {
+			if !chord[CalculateNote(tuning[s], f)] {
+				continue
+			}
+			if f == 0 {
+				frets[s] = 0
+				walk(s+1, low, high)
+				continue
+			}
+			newLow, newHigh := min(low, f), max(high, f)
+			if newHigh-newLow >= span {
+				continue
+			}
+			frets[s] = f
+			walk(s+1, newLow, newHigh)
+		}
+	}
+	walk(0, fretCount+1, -1)
+
+	sort.SliceStable(voicings, func(i, j int) bool {
+		pi, pj := voicings[i].Position(), voicings[j].Position()
+		if pi != pj {
+			return pi < pj
+		}
+		ri, rj := voicings[i].Inversion() == 0, voicings[j].Inversion() == 0
+		if ri != rj {
+			return ri
+		}
+		return len(voicings[i].Pitches) > len(voicings[j].Pitches)
+	})
+	return voicings
+}
+
+// newVoicing checks a fret choice and fingers it
+func newVoicing(root Note, quality ChordQuality, tuning []Note, frets []int, span int) (Voicing, bool) {
+	v := Voicing{Root: root, Quality: quality, Frets: append([]int(nil), frets...)}
+
+	var played [12]bool
+	first, last, sounding, highest := -1, -1, 0, 0
+	opens := TuningPitches(tuning)
+	for s, f := range frets {
+		if f < 0 {
+			continue
+		}
+		highest = max(highest, f)
+		if first == -1 {
+			first = s
+		}
+		last = s
+		sounding++
+		played[CalculateNote(tuning[s], f)] = true
+		v.Pitches = append(v.Pitches, CalculatePitch(opens[s], f))
+	}
+	if sounding < min(3, len(quality.Intervals)) || last-first+1-sounding > 1 {
+		return v, false
+	}
+	if v.hasOpen() && highest > span {
+		return v, false
+	}
+	sort.Slice(v.Pitches, func(i, j int) bool { return v.Pitches[i] < v.Pitches[j] })
+	for _, interval := range quality.Intervals {
+		if !played[(int(root)+interval)%12] && (interval != 7 || len(quality.Intervals) < 4) {
+			return v, false
+		}
+	}
+
+	fingers, ok := suggestFingers(frets)
+	if !ok {
+		return v, false
+	}
+	v.Fingers = fingers
+	return v, true
+}
+
+// suggestFingers assigns fingers in fret order (low strings first on the
+// same fret). With more than four fretted notes the index finger bars the
+// lowest fret, which needs no open string under the barre.
+func suggestFingers(frets []int) ([]int, bool) {
+	type note struct{ s, f int }
+	var fretted []note
+	lowest := -1
+	for s, f := range frets {
+		if f > 0 {
+			fretted = append(fretted, note{s, f})
+			if lowest == -1 || f < lowest {
+				lowest = f
+			}
+		}
+	}
+	sort.SliceStable(fretted, func(i, j int) bool {
+		return fretted[i].f < fretted[j].f
+	})
+
+	fingers := make([]int, len(frets))
+	if len(fretted) <= 4 {
+		for i, n := range fretted {
+			fingers[n.s] = i + 1
+		}
+		return fingers, true
+	}
+
+	// Barre: finger 1 across the lowest fret, from its lowest to highest string
+	barreFrom, barreTo := -1, -1
+	for _, n := range fretted {
+		if n.f == lowest {
+			if barreFrom == -1 {
+				barreFrom = n.s
+			}
+			barreTo = max(barreTo, n.s)
+		}
+	}
+	for s := barreFrom; s <= barreTo; s++ {
+		if frets[s] == 0 {
+			return nil, false
+		}
+	}
+	finger := 2
+	for _, n := range fretted {
+		if n.f == lowest {
+			fingers[n.s] = 1
+			continue
+		}
+		if finger > 4 {
+			return nil, false
+		}
+		fingers[n.s] = finger
+		finger++
+	}
+	return fingers, true
+}
+
+// Bass is the lowest sounding note
+func (v Voicing) Bass() Note {
+	if len(v.Pitches) == 0 {
+		return v.Root
+	}
+	return v.Pitches[0].Note()
+}
+
+// Position is the lowest fretted fret (0 for open shapes)
+func (v Voicing) Position() int {
+	lowest := 0
+	for _, f := range v.Frets {
+		if f > 0 && (lowest == 0 || f < lowest) {
+			lowest = f
+		}
+	}
+	if lowest > 0 && v.hasOpen() {
+		return 0
+	}
+	return lowest
+}
+
+func (v Voicing) hasOpen() bool {
+	for _, f := range v.Frets {
+		if f == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Chord returns the voicing read as a chord, for its name and inversion
+func (v Voicing) Chord() ChordMatch {
+	return ChordMatch{Root: v.Root, Bass: v.Bass(), Quality: v.Quality}
+}
+
+// Inversion is the chord inversion (see ChordMatch.Inversion)
+func (v Voicing) Inversion() int {
+	return v.Chord().Inversion()
+}
+
+// OnStrings reports whether the voicing sounds exactly the given strings
+// (nil = any strings)
+func (v Voicing) OnStrings(set []int) bool {
+	if set == nil {
+		return true
+	}
+	sounding := 0
+	for _, f := range v.Frets {
+		if f >= 0 {
+			sounding++
+		}
+	}
+	if sounding != len(set) {
+		return false
+	}
+	for _, s := range set {
+		if s >= len(v.Frets) || v.Frets[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Drop classifies four-note voicings: 0 = close (within an octave), 2 =
+// drop 2, 3 = drop 3, -1 = other or not four different notes
+func (v Voicing) Drop() int {
+	if len(v.Pitches) != 4 {
+		return -1
+	}
+	var seen [12]bool
+	for _, p := range v.Pitches {
+		if seen[p.Note()] {
+			return -1
+		}
+		seen[p.Note()] = true
+	}
+	p := v.Pitches
+	raised := p[0] + 12
+	switch {
+	case p[3]-p[0] < 12:
+		return 0
+	case p[2] < raised && raised < p[3] && p[3]-p[1] < 12:
+		return 2
+	case p[1] < raised && raised < p[2] && p[3]-p[1] < 12:
+		return 3
+	}
+	return -1
+}
+
+// String writes the shape the way chord charts do, low E first: "x32010",
+// with dashes once a fret has two digits: "x-10-12-12-11-x"
+func (v Voicing) String() string {
+	parts := make([]string, len(v.Frets))
+	wide := false
+	for i, f := range v.Frets {
+		if f < 0 {
+			parts[i] = "x"
+			continue
+		}
+		parts[i] = fmt.Sprint(f)
+		wide = wide || f > 9
+	}
+	if wide {
+		return strings.Join(parts, "-")
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,77 @@
+package theory
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindVoicings(t *testing.T) {
+	tests := []struct {
+		root    Note
+		quality string
+		shapes  []string // Must be among the voicings
+	}{
+		{C, "", []string{"x32010", "x35553", "8-10-10-9-8-8"}},
+		{G, "", []string{"320003", "355433"}},
+		{A, "m", []string{"x02210", "577555"}},
+		{E, "7", []string{"020100"}},
+		{D, "m7", []string{"xx0211"}},
+		{C, "maj7", []string{"x32000", "x35453"}},
+	}
+	for _, tt := range tests {
+		quality := qualityBySuffix(t, tt.quality)
+		voicings := FindVoicings(tt.root, quality, StandardTuning, 12, DefaultVoicingSpan)
+		var shapes []string
+		for _, v := range voicings {
+			shapes = append(shapes, v.String())
+			// Every chord tone sounds, the 5th may be left out
+			played := map[Note]bool{}
+			for _, p := range v.Pitches {
+				played[p.Note()] = true
+			}
+			for _, interval := range quality.Intervals {
+				if !played[Note((int(tt.root)+interval)%12)] && (interval != 7 || len(quality.Intervals) < 4) {
+					t.Errorf("%s%s %s leaves out interval %d", NoteNames[tt.root], tt.quality, v, interval)
+				}
+			}
+		}
+		for _, want := range tt.shapes {
+			if !slices.Contains(shapes, want) {
+				t.Errorf("%s%s: %s not found", NoteNames[tt.root], tt.quality, want)
+			}
+		}
+	}
+}
+
+func TestVoicingDrop(t *testing.T) {
+	tests := []struct {
+		frets []int
+		drop  int
+	}{
+		{[]int{-1, 3, 5, 4, 5, -1}, 2},  // Cmaj7 drop 2: C G B E
+		{[]int{8, -1, 9, 9, 8, -1}, 3},  // Cmaj7 drop 3: C B E G
+		{[]int{-1, -1, 10, 9, 8, 7}, 0}, // Cmaj7 close: C E G B
+	}
+	cmaj7 := qualityBySuffix(t, "maj7")
+	for _, tt := range tests {
+		v, ok := newVoicing(C, cmaj7, StandardTuning, tt.frets, DefaultVoicingSpan)
+		if !ok {
+			t.Errorf("%v is not a Cmaj7 voicing", tt.frets)
+			continue
+		}
+		if got := v.Drop(); got != tt.drop {
+			t.Errorf("%s: drop %d, want %d", v, got, tt.drop)
+		}
+	}
+}
+
+func qualityBySuffix(t *testing.T, suffix string) ChordQuality {
+	t.Helper()
+	for _, q := range ChordQualities {
+		if q.Suffix == suffix {
+			return q
+		}
+	}
+	t.Fatalf("no chord quality %q", suffix)
+	return ChordQuality{}
+}
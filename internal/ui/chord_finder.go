@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"guitui/internal/theory"
+)
+
+// chordFinder is the chord library browser: pick a root and chord type, then
+// step through its voicings, filtered by string set and inversion. The
+// triad map shows every triad shape on the three-string sets at once.
+type chordFinder struct {
+	active    bool
+	root      theory.Note
+	quality   int // Index into theory.ChordQualities
+	stringSet int // Index into theory.StringSets
+	inversion int // -1 = any, 0 = root position, 1-3 = inversions
+	voicing   int // Index into the filtered voicings
+	triadMap  bool
+	found     *chordSearches // Search results, shared by the copies View renders
+}
+
+// chordSearch identifies one voicing or triad search
+type chordSearch struct {
+	root      theory.Note
+	quality   int
+	tuning    string
+	fretCount int
+}
+
+// chordSearches keeps the FindVoicings and FindTriads results of every chord
+// looked at, so browsing does not repeat the search on each frame
+type chordSearches struct {
+	voicings map[chordSearch][]theory.Voicing
+	triads   map[chordSearch][]theory.TriadGroup
+}
+
+func newChordSearches() *chordSearches {
+	return &chordSearches{
+		voicings: make(map[chordSearch][]theory.Voicing),
+		triads:   make(map[chordSearch][]theory.TriadGroup),
+	}
+}
+
+// chordFinderHelp lists the keys of the chord finder
+const chordFinderHelp = "r/R root • n/N type • ←/→ voicing • t/T strings • i inversion • m triad map • e arpeggio • C/esc close"
+
+// triadMapHelp lists the keys of the triad map
+const triadMapHelp = "r/R root • n/N triad • ←/→ shape • t/T strings • i inversion • m voicings • C/esc close"
+
+// voicings returns the voicings that pass the string set and inversion filters
+func (c chordFinder) voicings(tuning []theory.Note, fretCount int) []theory.Voicing {
+	if c.triadMap {
+		return c.triads(tuning, fretCount)
+	}
+	set := theory.StringSets[c.stringSet].Strings
+	var filtered []theory.Voicing
+	for _, v := range c.allVoicings(tuning, fretCount) {
+		if !v.OnStrings(set) {
+			continue
+		}
+		if c.inversion >= 0 && v.Inversion() != c.inversion {
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+	return filtered
+}
+
+// triads returns the triad shapes that pass the filters, by string set from
+// the low strings up, then by inversion
+func (c chordFinder) triads(tuning []theory.Note, fretCount int) []theory.Voicing {
+	var shapes []theory.Voicing
+	for _, group := range c.triadGroups(tuning, fretCount) {
+		for inversion, voicings := range group.Inversions {
+			if c.inversion < 0 || c.inversion == inversion {
+				shapes = append(shapes, voicings...)
+			}
+		}
+	}
+	return shapes
+}
+
+// triadGroups returns the triad shapes of the chord on the selected string
+// set, or on every three-string set when no set is selected
+func (c chordFinder) triadGroups(tuning []theory.Note, fretCount int) []theory.TriadGroup {
+	set := theory.StringSets[c.stringSet].Strings
+	var groups []theory.TriadGroup
+	for _, group := range c.allTriads(tuning, fretCount) {
+		if set == nil || slices.Equal(set, group.Set.Strings) {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// search identifies the chord's search in a tuning
+func (c chordFinder) search(tuning []theory.Note, fretCount int) chordSearch {
+	return chordSearch{root: c.root, quality: c.quality, tuning: fmt.Sprint(tuning), fretCount: fretCount}
+}
+
+// allVoicings returns every voicing of the chord, searched once per chord,
+// tuning and fret count
+func (c chordFinder) allVoicings(tuning []theory.Note, fretCount int) []theory.Voicing {
+	find := func() []theory.Voicing {
+		return theory.FindVoicings(c.root, theory.ChordQualities[c.quality], tuning, fretCount, theory.DefaultVoicingSpan)
+	}
+	if c.found == nil {
+		return find()
+	}
+	search := c.search(tuning, fretCount)
+	voicings, ok := c.found.voicings[search]
+	if !ok {
+		voicings = find()
+		c.found.voicings[search] = voicings
+	}
+	return voicings
+}
+
+// allTriads returns the triad shapes of the chord on every three-string
+// set, searched once per chord, tuning and fret count
+func (c chordFinder) allTriads(tuning []theory.Note, fretCount int) []theory.TriadGroup {
+	find := func() []theory.TriadGroup {
+		return theory.FindTriads(c.root, theory.ChordQualities[c.quality], tuning, fretCount)
+	}
+	if c.found == nil {
+		return find()
+	}
+	search := c.search(tuning, fretCount)
+	groups, ok := c.found.triads[search]
+	if !ok {
+		groups = find()
+		c.found.triads[search] = groups
+	}
+	return groups
+}
+
+// usable reports whether the triad map can show a chord type and string set
+func (c chordFinder) usable(quality, stringSet int) bool {
+	if !c.triadMap {
+		return true
+	}
+	if quality >= 0 && !theory.ChordQualities[quality].Triad() {
+		return false
+	}
+	set := theory.StringSets[stringSet].Strings
+	return set == nil || len(set) == 3
+}
+
+// key spells the chord: minor chords as minor keys
+func (c chordFinder) key() theory.Key {
+	return theory.DefaultKey(c.root, theory.ChordQualities[c.quality].Minor())
+}
+
+// name is the chord symbol: "Cmaj7", "F#m7b5"
+func (c chordFinder) name() string {
+	return c.key().Spell(c.root) + theory.ChordQualities[c.quality].Suffix
+}
+
+// harmony is the chord over its own key, for interval labels
+func (c chordFinder) harmony() theory.Harmony {
+	chord := theory.ChordMatch{Root: c.root, Bass: c.root, Quality: theory.ChordQualities[c.quality]}
+	return chord.Harmony(c.key())
+}
+
+// filterLabel describes the active filters: "strings 1-4, 1st inversion"
+func (c chordFinder) filterLabel() string {
+	label := "all strings"
+	if c.triadMap {
+		label = "all string sets"
+	}
+	if set := theory.StringSets[c.stringSet]; set.Strings != nil {
+		label = "strings " + set.Name
+	}
+	if c.inversion >= 0 {
+		label += ", " + theory.InversionName(c.inversion)
+	}
+	return label
+}
+
+// handleKey updates the finder for a key press and reports whether the key
+// was used; other keys keep their normal meaning
+func (c *chordFinder) handleKey(key string) bool {
+	switch key {
+	case "C", "esc":
+		c.active = false
+	case "r", "R":
+		step := 1
+		if key == "R" {
+			step = 11
+		}
+		c.root = theory.Note((int(c.root) + step) % 12)
+		c.voicing = 0
+	case "n", "N":
+		step := 1
+		if key == "N" {
+			step = len(theory.ChordQualities) - 1
+		}
+		c.quality = (c.quality + step) % len(theory.ChordQualities)
+		for !c.usable(c.quality, c.stringSet) {
+			c.quality = (c.quality + step) % len(theory.ChordQualities)
+		}
+		c.voicing = 0
+	case "t", "T":
+		step := 1
+		if key == "T" {
+			step = len(theory.StringSets) - 1
+		}
+		c.stringSet = (c.stringSet + step) % len(theory.StringSets)
+		for !c.usable(-1, c.stringSet) {
+			c.stringSet = (c.stringSet + step) % len(theory.StringSets)
+		}
+		c.voicing = 0
+	case "i":
+		// any -> root position -> 1st -> 2nd -> 3rd -> any (triads stop at 2nd).
+		// I stays the interval label toggle.
+		c.inversion++
+		if c.inversion > 3 || c.triadMap && c.inversion > 2 {
+			c.inversion = -1
+		}
+		c.voicing = 0
+	case "m", "M":
+		c.toggleTriadMap()
+	case "right", "l":
+		c.voicing++
+	case "left", "h":
+		c.voicing--
+	default:
+		return false
+	}
+	return true
+}
+
+// toggleTriadMap switches between voicings and the triad map. The map keeps
+// the root and turns other chord types into a major or minor triad.
+func (c *chordFinder) toggleTriadMap() {
+	c.triadMap = !c.triadMap
+	c.voicing = 0
+	if !c.triadMap {
+		return
+	}
+	if quality := theory.ChordQualities[c.quality]; !quality.Triad() {
+		c.quality = 0 // Major
+		if quality.Minor() {
+			c.quality = 1
+		}
+	}
+	if !c.usable(-1, c.stringSet) {
+		c.stringSet = 0
+	}
+	if c.inversion > 2 {
+		c.inversion = -1
+	}
+}
+
+// current returns the selected voicing (wrapping the index) and the count
+func (c *chordFinder) current(tuning []theory.Note, fretCount int) (*theory.Voicing, int) {
+	voicings := c.voicings(tuning, fretCount)
+	if len(voicings) == 0 {
+		return nil, 0
+	}
+	c.voicing = (c.voicing%len(voicings) + len(voicings)) % len(voicings)
+	return &voicings[c.voicing], len(voicings)
+}
+
+// info is the info bar text: "CHORDS: Cmaj7 3/12 x32000 (root position, drop 2) • strings 1-4",
+// "TRIADS: Cm 2/9 xxx543 (root position) • strings 1-3"
+func (c chordFinder) info(v *theory.Voicing, count int) string {
+	if c.triadMap {
+		if v == nil {
+			return fmt.Sprintf("TRIADS: %s • no shapes (%s)", c.name(), c.filterLabel())
+		}
+		return fmt.Sprintf("TRIADS: %s %d/%d %s (%s) • %s",
+			v.Chord().Name(c.key()), c.voicing+1, count, v, v.Chord().InversionName(), c.filterLabel())
+	}
+	if v == nil {
+		return fmt.Sprintf("CHORDS: %s • no voicings (%s)", c.name(), c.filterLabel())
+	}
+	detail := v.Chord().InversionName()
+	if drop := v.Drop(); drop > 0 {
+		detail += fmt.Sprintf(", drop %d", drop)
+	} else if drop == 0 {
+		detail += ", close"
+	}
+	return fmt.Sprintf("CHORDS: %s %d/%d %s (%s) • %s",
+		v.Chord().Name(c.key()), c.voicing+1, count, v, detail, c.filterLabel())
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestChordFinderCachesSearches(t *testing.T) {
+	c := chordFinder{active: true, inversion: -1, found: newChordSearches()}
+	first := c.allVoicings(theory.StandardTuning, 12)
+	if len(first) == 0 {
+		t.Fatal("no C voicings")
+	}
+	// View works on copies of the finder; they share the results
+	copied := c
+	again := copied.allVoicings(theory.StandardTuning, 12)
+	if &again[0] != &first[0] {
+		t.Error("the same chord was searched twice")
+	}
+	if len(c.found.voicings) != 1 {
+		t.Errorf("%d searches cached, want 1", len(c.found.voicings))
+	}
+
+	// Another fret count or tuning is another search
+	c.allVoicings(theory.StandardTuning, 24)
+	c.allVoicings([]theory.Note{theory.D, theory.A, theory.D, theory.G, theory.B, theory.E}, 12)
+	c.allTriads(theory.StandardTuning, 12)
+	c.allTriads(theory.StandardTuning, 12)
+	if len(c.found.voicings) != 3 || len(c.found.triads) != 1 {
+		t.Errorf("cached %d voicing and %d triad searches, want 3 and 1", len(c.found.voicings), len(c.found.triads))
+	}
+}
+
+func TestChordFinderKeys(t *testing.T) {
+	tests := []struct {
+		key       string
+		used      bool
+		inversion int
+	}{
+		{"i", true, 0},
+		{"I", false, -1}, // Interval labels
+		{"f", false, -1}, // Fret count
+	}
+	for _, tt := range tests {
+		c := chordFinder{active: true, inversion: -1, found: newChordSearches()}
+		if used := c.handleKey(tt.key); used != tt.used {
+			t.Errorf("%q used = %v, want %v", tt.key, used, tt.used)
+		}
+		if c.inversion != tt.inversion {
+			t.Errorf("%q: inversion %d, want %d", tt.key, c.inversion, tt.inversion)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"guitui/internal/theory"
+)
+
+// circleNav is the interactive circle of fifths: a working key and mode to
+// move around, and a diatonic chord to light up on the fretboard
+type circleNav struct {
+	active   bool
+	key      theory.Key // Working major key
+	mode     int        // Index into theory.ModeNames
+	selected int        // Diatonic chord 0-6, -1 = none
+}
+
+// circleNavHelp lists the keys of the circle mode
+const circleNavHelp = "←/→ key • ↑/↓ mode • 1-7 chord • 0 clear • K/esc close"
+
+// open starts on a lesson key: minor keys as aeolian of their relative major
+func (c *circleNav) open(k theory.Key) {
+	c.active = true
+	c.key, c.mode = k, 0
+	if k.Minor {
+		c.key, c.mode = k.Relative(), 5
+	}
+	c.selected = -1
+}
+
+// handleKey updates the circle for a key press and reports whether the key
+// was used; other keys keep their normal meaning
+func (c *circleNav) handleKey(key string) bool {
+	switch key {
+	case "K", "esc":
+		c.active = false
+	case "right", "l": // Clockwise: up a fifth
+		c.key = theory.DefaultKey(theory.Note((int(c.key.Root())+7)%12), false)
+	case "left", "h": // Counter-clockwise: up a fourth
+		c.key = theory.DefaultKey(theory.Note((int(c.key.Root())+5)%12), false)
+	case "down", "j":
+		c.mode = (c.mode + 1) % len(theory.ModeNames)
+	case "up", "k":
+		c.mode = (c.mode + len(theory.ModeNames) - 1) % len(theory.ModeNames)
+	case "1", "2", "3", "4", "5", "6", "7":
+		degree := int(key[0] - '1')
+		if c.selected == degree {
+			degree = -1
+		}
+		c.selected = degree
+	case "0":
+		c.selected = -1
+	default:
+		return false
+	}
+	return true
+}
+
+// chords returns the diatonic chords of the working mode
+func (c circleNav) chords() []theory.DiatonicChord {
+	return theory.ModeChords(c.key, c.mode)
+}
+
+// chord returns the selected chord, if any
+func (c circleNav) chord() (theory.DiatonicChord, bool) {
+	if c.selected < 0 {
+		return theory.DiatonicChord{}, false
+	}
+	return c.chords()[c.selected], true
+}
+
+// harmony is the selected chord over the mode, or the mode's tonic chord
+func (c circleNav) harmony() theory.Harmony {
+	chord, ok := c.chord()
+	if !ok {
+		chord = c.chords()[0]
+	}
+	match := theory.ChordMatch{Root: chord.Root.Note(), Bass: chord.Root.Note(), Quality: chord.Seventh}
+	return match.Harmony(c.key)
+}
+
+// info is the info bar text: "CIRCLE: D Dorian of C • no ♯/♭ • relative Am"
+func (c circleNav) info() string {
+	info := "CIRCLE: " + theory.ModeName(c.key, c.mode)
+	if c.mode != 0 {
+		info += " of " + c.key.String()
+	}
+	info += " • " + c.key.SignatureString() + " • relative " + c.key.Relative().String()
+	if chord, ok := c.chord(); ok {
+		info += " • " + chord.Numeral + " " + chord.Root.String() + chord.Seventh.Suffix
+	}
+	return info
+}
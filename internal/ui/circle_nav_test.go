@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+
+	"guitui/internal/theory"
+)
+
+func TestCircleNav(t *testing.T) {
+	tests := []struct {
+		open string
+		keys []string
+		info string
+	}{
+		{"C", nil, "CIRCLE: C Ionian • no ♯/♭ • relative Am"},
+		{"Am", nil, "CIRCLE: A Aeolian of C • no ♯/♭ • relative Am"},
+		{"C", []string{"right", "right"}, "CIRCLE: D Ionian • 2♯ • relative Bm"},
+		{"C", []string{"left", "down"}, "CIRCLE: G Dorian of F • 1♭ • relative Dm"},
+		{"C", []string{"up"}, "CIRCLE: B Locrian of C • no ♯/♭ • relative Am"},
+		{"G", []string{"5"}, "CIRCLE: G Ionian • 1♯ • relative Em • V D7"},
+		{"G", []string{"5", "5"}, "CIRCLE: G Ionian • 1♯ • relative Em"}, // Same number again: off
+		{"G", []string{"2", "0"}, "CIRCLE: G Ionian • 1♯ • relative Em"},
+	}
+	for _, tt := range tests {
+		k, _ := theory.ParseKey(tt.open)
+		var c circleNav
+		c.open(k)
+		for _, key := range tt.keys {
+			if !c.handleKey(key) {
+				t.Fatalf("%q not used", key)
+			}
+		}
+		if got := c.info(); got != tt.info {
+			t.Errorf("%s %v: %q, want %q", tt.open, tt.keys, got, tt.info)
+		}
+	}
+
+	var c circleNav
+	c.open(theory.DefaultKey(theory.C, false))
+	if c.handleKey("q") || c.handleKey("i") {
+		t.Error("the circle kept a key it has no use for")
+	}
+	if !c.handleKey("esc") || c.active {
+		t.Error("esc did not close the circle")
+	}
+}
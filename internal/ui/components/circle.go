@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"math"
 	"strings"
 
@@ -9,18 +10,22 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Map Relative Minor (Giọng thứ song song)
-var minorMap = map[theory.Note]string{
-	theory.C: "Am", theory.G: "Em", theory.D: "Bm", theory.A: "F#m", theory.E: "C#m", theory.B: "G#m",
-	theory.Fs: "D#m", theory.Cs: "Bbm", theory.Gs: "Fm", theory.Ds: "Cm", theory.As: "Gm", theory.F: "Dm",
-}
-
 var circleOrder = []theory.Note{
 	theory.C, theory.G, theory.D, theory.A, theory.E, theory.B,
 	theory.Fs, theory.Cs, theory.Gs, theory.Ds, theory.As, theory.F,
 }
 
-func RenderCircle(activeNote theory.Note) string {
+// CircleProps contains data for rendering the circle of fifths
+type CircleProps struct {
+	Key     theory.Key // Highlighted key (a minor key lights its relative major)
+	Focused bool       // K mode: show the working key in the middle
+	Mode    int        // Mode of the major key (index into theory.ModeNames)
+}
+
+// RenderCircle draws the circle of fifths with the key highlighted. When
+// focused it also lights the neighbouring keys (IV and V, with their relative
+// minors) and writes the key, mode and signature in the middle.
+func RenderCircle(props CircleProps) string {
 	const (
 		width   = 36
 		height  = 13
@@ -43,11 +48,17 @@ func RenderCircle(activeNote theory.Note) string {
 	angleStep := 2 * math.Pi / 12
 	startAngle := -math.Pi / 2
 
+	major := props.Key
+	if major.Minor {
+		major = major.Relative()
+	}
+	activeNote := major.Root()
+
 	for i, note := range circleOrder {
 		theta := startAngle + float64(i)*angleStep
 
-		majName := theory.NoteNames[note]
-		minName := minorMap[note]
+		majName := theory.DefaultKey(note, false).String()
+		minName := relativeMinor(note).String()
 
 		// Default Style
 		majStyle := lipgloss.NewStyle().Foreground(theory.CatOverlay1).Bold(true)
@@ -59,6 +70,10 @@ func RenderCircle(activeNote theory.Note) string {
 			color := theory.NoteColors[note]
 			majStyle = majStyle.Foreground(color).Underline(true)
 			minStyle = minStyle.Foreground(theory.CatText).Bold(true)
+		} else if props.Focused && isNeighbour(note, activeNote) {
+			// Chords IV and V of the key, ii and iii inside
+			majStyle = majStyle.Foreground(theory.CatText)
+			minStyle = minStyle.Foreground(theory.CatSubtext1)
 		}
 
 		// Vẽ Outer (Major)
@@ -67,6 +82,25 @@ func RenderCircle(activeNote theory.Note) string {
 		renderAt(canvas, width, height, centerX, centerY, rInnerX, rInnerY, theta, minStyle.Render(minName))
 	}
 
+	if props.Focused {
+		lines := []string{
+			theory.ModeName(major, props.Mode),
+			props.Key.SignatureString(),
+		}
+		if props.Mode != 0 {
+			lines = append(lines, "of "+major.String())
+		}
+		styles := []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(theory.NoteColors[activeNote]).Bold(true),
+			lipgloss.NewStyle().Foreground(theory.CatSubtext1),
+			lipgloss.NewStyle().Foreground(theory.CatOverlay1),
+		}
+		top := height/2 - len(lines)/2
+		for i, line := range lines {
+			writeCentered(canvas, width, top+i, styles[i].Render(line))
+		}
+	}
+
 	var sb strings.Builder
 	for _, row := range canvas {
 		sb.WriteString(strings.Join(row, "") + "\n")
@@ -74,6 +108,48 @@ func RenderCircle(activeNote theory.Note) string {
 	return sb.String() // Đã trim suffix ở logic cũ nếu cần thì trim ở Model
 }
 
+// RenderDiatonicRow lists the diatonic chords under their numerals, e.g.
+// "i Dm  ii Em  bIII F ...", with the selected chord (-1 = none) highlighted
+func RenderDiatonicRow(chords []theory.DiatonicChord, selected int) string {
+	numeralStyle := lipgloss.NewStyle().Foreground(theory.CatOverlay1)
+	nameStyle := lipgloss.NewStyle().Foreground(theory.CatText).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(theory.CatCrust).Background(theory.CatGreen).Bold(true)
+
+	parts := make([]string, len(chords))
+	for i, c := range chords {
+		name := nameStyle.Render(c.Name())
+		if i == selected {
+			name = selectedStyle.Render(c.Root.String() + c.Seventh.Suffix)
+		}
+		parts[i] = numeralStyle.Render(fmt.Sprintf("%d:%s ", i+1, c.Numeral)) + name
+	}
+	return strings.Join(parts, "  ")
+}
+
+// relativeMinor returns the relative minor key (giọng thứ song song) of a major key
+func relativeMinor(major theory.Note) theory.Key {
+	return theory.DefaultKey(theory.Note((int(major)+9)%12), true)
+}
+
+// isNeighbour reports whether two keys are a fifth apart on the circle
+func isNeighbour(a, b theory.Note) bool {
+	d := (int(a) - int(b) + 12) % 12
+	return d == 5 || d == 7
+}
+
+// writeCentered puts text in the middle of a canvas row
+func writeCentered(canvas [][]string, w, y int, text string) {
+	txtLen := lipgloss.Width(text)
+	x := (w - txtLen) / 2
+	if y < 0 || y >= len(canvas) || x < 0 {
+		return
+	}
+	canvas[y][x] = text
+	for k := 1; k < txtLen && x+k < w; k++ {
+		canvas[y][x+k] = ""
+	}
+}
+
 func renderAt(canvas [][]string, w, h int, cx, cy, rx, ry, theta float64, text string) {
 	txtLen := lipgloss.Width(text)
 	x := int(cx+rx*math.Cos(theta)) - txtLen/2
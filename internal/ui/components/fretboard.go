@@ -20,6 +20,24 @@ var (
 	fretLineStyle = lipgloss.NewStyle().Foreground(theory.CatOverlay1)
 	nutStyle      = lipgloss.NewStyle().Foreground(theory.CatText).Bold(true)
 	inlayStyle    = lipgloss.NewStyle().Foreground(theory.CatSurface1)
+	capoStyle     = lipgloss.NewStyle().Foreground(theory.CatRosewater).Bold(true)
+	behindCapo    = fretLineStyle.Faint(true)
+
+	// Scale overlay: root stands out, other scale notes stay in the background
+	overlayRootStyle = lipgloss.NewStyle().Bold(true).Foreground(theory.CatRed).Background(theory.CatSurface1)
+	overlayNoteStyle = lipgloss.NewStyle().Foreground(theory.CatSubtext1).Faint(true)
+
+	// Circle chord tones: root stands out like the overlay root
+	chordRootStyle = lipgloss.NewStyle().Bold(true).Foreground(theory.CatCrust).Background(theory.CatRed)
+	chordToneStyle = lipgloss.NewStyle().Bold(true).Foreground(theory.CatCrust).Background(theory.CatGreen)
+
+	// Interval mode: chord tones, tensions, avoid notes and outside notes
+	intervalColors = map[theory.ToneFunction]lipgloss.Color{
+		theory.ToneChord:   theory.CatGreen,
+		theory.ToneTension: theory.CatBlue,
+		theory.ToneAvoid:   theory.CatRed,
+		theory.ToneOutside: theory.CatOverlay1,
+	}
 	
 	// Upcoming note patterns (distance 1, 2, 3)
 	upcomingPatterns = []string{" ● ", " : ", " ∴ "}
@@ -67,8 +85,9 @@ type cellData struct {
 
 // SequenceItem represents a note in scale sequence
 type SequenceItem struct {
-	Order  int // Beat/step order (1-based)
-	Finger int // Finger number (0-4)
+	Order  int         // Beat/step order (1-based)
+	Finger int         // Finger number (0-4)
+	Note   theory.Note // Note played (interval mode)
 }
 
 // ActiveItem represents currently playing note
@@ -90,11 +109,40 @@ type FretboardProps struct {
 	ScaleSequence   map[string]SequenceItem // All notes in lesson
 	Tuning          []theory.Note          // String tuning
 	FretCount       int                    // Number of frets to show
+	Capo            lesson.Capo            // Marker frets are already absolute
+	Key             theory.Key             // Spells note names (Bb, not A#)
 	
 	// Display modes
 	ShowAll        bool // Tab mode: show all notes
 	ShowScaleShape bool // S key: show scale pattern
 	ShowFingers    bool // H key: show finger numbers
+	AbsoluteFrets  bool // C key: label frets from the nut instead of the capo
+	ShowOctaves    bool // O key: tab mode labels with octave numbers (A3)
+	ShowIntervals  bool // I key: label notes by interval against Harmony
+
+	Harmony theory.Harmony      // Chord and scale the interval labels refer to
+	Chords  []theory.ChordMatch // Chord of the active notes, best first
+
+	// Scale overlay (V key): every note of the scale under the lesson markers
+	OverlayScale string      // theory.Scales key, empty = off
+	OverlayRoot  theory.Note // Root of the overlay scale
+
+	// Position browsing (P key): one CAGED/3NPS position of the overlay scale
+	Position *theory.Position // nil = off
+
+	// Circle of fifths (K key): tones of the selected diatonic chord, root first
+	ChordTones []theory.SpelledNote
+
+	// Chord finder (Shift+C): the voicing to show instead of the lesson
+	Voicing *theory.Voicing // nil = off
+
+	// Triad map (M in the chord finder): every shape, colored by chord tone
+	Triads []theory.Voicing
+
+	// Note quiz (Shift+Q): the lesson is hidden, only the question shows
+	QuizAsk    string          // "s_f" of the fret to name, "" = none
+	QuizCursor string          // "s_f" of the find cursor, "" = none
+	QuizFound  map[string]theory.Note // Frets found so far and their note
 }
 
 // --- HELPER FUNCTIONS ---
@@ -107,6 +155,28 @@ func formatOrder3Chars(n int) string {
 	return fmt.Sprintf(" %d", n)
 }
 
+// displayFret returns the fret number to show for an absolute fret:
+// capo-relative on covered strings unless absolute display is on
+func displayFret(props FretboardProps, stringIdx, fret int) int {
+	if props.AbsoluteFrets {
+		return fret
+	}
+	return props.Capo.RelativeFret(stringIdx, fret)
+}
+
+// noteCell fits a note name in a 3-char cell: " C ", " Bb", "F##"
+func noteCell(name string) string {
+	if len(name) >= 3 {
+		return name[:3]
+	}
+	return fmt.Sprintf(" %-2s", name)
+}
+
+// intervalCell returns the interval label of a note and its function color
+func intervalCell(props FretboardProps, note theory.Note) (string, lipgloss.Color) {
+	return noteCell(props.Harmony.Label(note)), intervalColors[props.Harmony.Function(note)]
+}
+
 // getFingerStyle returns the appropriate style for a finger
 func getFingerStyle(finger int, background bool) lipgloss.Style {
 	if background {
@@ -128,9 +198,16 @@ func RenderFretboard(props FretboardProps) string {
 	grid := make(map[string]cellData)
 
 	// Build display grid in layers (lower priority first)
+	buildScaleOverlayLayer(grid, props)
+	buildPositionLayer(grid, props)
+	buildChordTonesLayer(grid, props)
+	buildTriadMapLayer(grid, props)
 	buildBackgroundLayer(grid, props)
 	buildUpcomingLayer(grid, props)
 	buildActiveLayer(grid, props)
+	buildBendGhostLayer(grid, props)
+	buildVoicingLayer(grid, props)
+	buildQuizLayer(grid, props)
 
 	// Render the grid to string
 	output := renderGrid(grid, props)
@@ -145,12 +222,142 @@ func RenderFretboard(props FretboardProps) string {
 	if pickLine != "" {
 		output += pickLine + "\n"
 	}
+	if chordLine := renderChordLine(props); chordLine != "" {
+		output += chordLine + "\n"
+	}
 	
 	return output
 }
 
+// buildScaleOverlayLayer draws every note of the overlay scale across the
+// neck, below all lesson layers
+func buildScaleOverlayLayer(grid map[string]cellData, props FretboardProps) {
+	if props.OverlayScale == "" {
+		return
+	}
+	formula, ok := theory.Scales[props.OverlayScale]
+	if !ok {
+		return
+	}
+	key := theory.ScaleKey(props.OverlayRoot, props.OverlayScale)
+	spelled := make(map[theory.Note]string)
+	for _, sn := range theory.SpellScale(key.Tonic, formula) {
+		spelled[sn.Note()] = sn.String()
+	}
+
+	for s := 0; s < 6; s++ {
+		for f := 0; f <= props.FretCount; f++ {
+			note := theory.CalculateNote(props.Tuning[s], f)
+			name, inScale := spelled[note]
+			if !inScale {
+				continue
+			}
+			style := overlayNoteStyle
+			if note == props.OverlayRoot {
+				style = overlayRootStyle
+			}
+			text := noteCell(name)
+			if props.ShowIntervals {
+				var color lipgloss.Color
+				text, color = intervalCell(props, note)
+				style = style.Foreground(color)
+			}
+			grid[fmt.Sprintf("%d_%d", s, f)] = cellData{
+				text:     text,
+				style:    style,
+				priority: 0,
+			}
+		}
+	}
+}
+
+// buildPositionLayer highlights the notes of the browsed position with the
+// finger colors, over the scale overlay and below the lesson layers
+func buildPositionLayer(grid map[string]cellData, props FretboardProps) {
+	if props.Position == nil || props.OverlayScale == "" {
+		return
+	}
+	key := theory.ScaleKey(props.OverlayRoot, props.OverlayScale)
+	rootFret := (int(props.OverlayRoot) - int(props.Tuning[0]) + 12) % 12
+	start, end := theory.CalculateFretRange(*props.Position, rootFret)
+	// Keep the position on screen: the same shape sits an octave lower
+	if end > props.FretCount && start >= 12 {
+		start -= 12
+	}
+
+	pos := props.Position
+	for s, pattern := range pos.NotePatterns {
+		if s >= len(props.Tuning) {
+			break
+		}
+		for i, rel := range pattern.RelativeFrets {
+			f := start + rel
+			if f > props.FretCount {
+				continue
+			}
+			finger := 0
+			if i < len(pos.FingerPattern[s]) {
+				finger = pos.FingerPattern[s][i]
+			}
+			note := theory.CalculateNote(props.Tuning[s], f)
+			style := getFingerStyle(finger, true)
+			if note == props.OverlayRoot {
+				style = style.Bold(true).Underline(true)
+			}
+			text := noteCell(key.Spell(note))
+			if props.ShowIntervals {
+				text, _ = intervalCell(props, note)
+			}
+			grid[fmt.Sprintf("%d_%d", s, f)] = cellData{
+				text:     text,
+				style:    style,
+				priority: 0,
+			}
+		}
+	}
+}
+
+// buildChordTonesLayer lights every fret that plays a tone of the selected
+// chord, root in red, under the lesson layers
+func buildChordTonesLayer(grid map[string]cellData, props FretboardProps) {
+	if len(props.ChordTones) == 0 {
+		return
+	}
+	root := props.ChordTones[0].Note()
+	for s := 0; s < len(props.Tuning); s++ {
+		for f := 0; f <= props.FretCount; f++ {
+			note := theory.CalculateNote(props.Tuning[s], f)
+			for _, tone := range props.ChordTones {
+				if tone.Note() != note {
+					continue
+				}
+				text := noteCell(tone.String())
+				if props.ShowIntervals {
+					text, _ = intervalCell(props, note)
+				}
+				style := chordToneStyle
+				if note == root {
+					style = chordRootStyle
+				}
+				grid[fmt.Sprintf("%d_%d", s, f)] = cellData{
+					text:     text,
+					style:    style,
+					priority: 0,
+				}
+				break
+			}
+		}
+	}
+}
+
 // buildBackgroundLayer builds Layer 0: background display modes
 func buildBackgroundLayer(grid map[string]cellData, props FretboardProps) {
+	// Interval Mode - Show every lesson note by its function
+	if props.ShowIntervals {
+		buildIntervalMode(grid, props)
+		return
+	}
+
 	// Mode 1: Tab Mode - Show ALL notes on entire fretboard
 	if props.ShowAll {
 		buildTabMode(grid, props)
@@ -170,12 +377,22 @@ func buildBackgroundLayer(grid map[string]cellData, props FretboardProps) {
 	}
 }
 
+// noteLabel returns the tab mode label of a fret: note name, or pitch with octave
+func noteLabel(props FretboardProps, openPitches []theory.Pitch, stringIdx, fret int) string {
+	if props.ShowOctaves {
+		return fmt.Sprintf("%-3s", theory.CalculatePitch(openPitches[stringIdx], fret).Spell(props.Key))
+	}
+	return fmt.Sprintf("%-3s", props.Key.Spell(theory.CalculateNote(props.Tuning[stringIdx], fret)))
+}
+
 // buildTabMode displays all notes on fretboard
 func buildTabMode(grid map[string]cellData, props FretboardProps) {
+	openPitches := theory.TuningPitches(props.Tuning)
 	for s := 0; s < 6; s++ {
 		for f := 0; f <= props.FretCount; f++ {
 			note := theory.CalculateNote(props.Tuning[s], f)
 			key := fmt.Sprintf("%d_%d", s, f)
+			label := noteLabel(props, openPitches, s, f)
 
 			// Check if note is in scale sequence
 			if seqItem, inScale := props.ScaleSequence[key]; inScale {
@@ -183,14 +400,14 @@ func buildTabMode(grid map[string]cellData, props FretboardProps) {
 				style := getFingerStyle(seqItem.Finger, true)
 				style = style.Copy().Foreground(lipgloss.Color("#000000")) // Black text
 				grid[key] = cellData{
-					text:     fmt.Sprintf("%-3s", theory.NoteNames[note]),
+					text:     label,
 					style:    style,
 					priority: 1,
 				}
 			} else {
 				// Tab only: note name with note color
 				grid[key] = cellData{
-					text:     fmt.Sprintf("%-3s", theory.NoteNames[note]),
+					text:     label,
 					style:    lipgloss.NewStyle().Foreground(theory.NoteColors[note]),
 					priority: 1,
 				}
@@ -199,6 +416,19 @@ func buildTabMode(grid map[string]cellData, props FretboardProps) {
 	}
 }
 
+// buildIntervalMode labels every note of the lesson with its interval,
+// colored by function (chord tone, tension, avoid, outside)
+func buildIntervalMode(grid map[string]cellData, props FretboardProps) {
+	for key, seqItem := range props.ScaleSequence {
+		text, color := intervalCell(props, seqItem.Note)
+		grid[key] = cellData{
+			text:     text,
+			style:    lipgloss.NewStyle().Foreground(color),
+			priority: 1,
+		}
+	}
+}
+
 // buildScaleShapeMode displays scale sequence numbers
 func buildScaleShapeMode(grid map[string]cellData, props FretboardProps) {
 	for key, seqItem := range props.ScaleSequence {
@@ -267,7 +497,12 @@ func buildActiveLayer(grid map[string]cellData, props FretboardProps) {
 		var displayText string
 		var style lipgloss.Style
 
-		if props.ShowScaleShape {
+		if props.ShowIntervals {
+			// Interval mode: function color as background
+			var color lipgloss.Color
+			displayText, color = intervalCell(props, m.Note)
+			style = lipgloss.NewStyle().Bold(true).Foreground(theory.CatCrust).Background(color)
+		} else if props.ShowScaleShape {
 			// Scale mode: show sequence number with underline
 			displayText = formatOrder3Chars(item.Order)
 			style = getFingerStyle(m.Finger, false)
@@ -283,7 +518,7 @@ func buildActiveLayer(grid map[string]cellData, props FretboardProps) {
 			style = style.Copy().Bold(true).Underline(true)
 		} else if props.ShowAll {
 			// Tab mode: show fret number only (no inline technique)
-			displayText = fmt.Sprintf("%-3d", m.Fret)
+			displayText = fmt.Sprintf("%-3d", displayFret(props, m.StringIndex, m.Fret))
 			// Inverted colors: background = note color, text = dark
 			note := theory.CalculateNote(props.Tuning[m.StringIndex], m.Fret)
 			style = lipgloss.NewStyle().
@@ -292,7 +527,7 @@ func buildActiveLayer(grid map[string]cellData, props FretboardProps) {
 				Background(theory.NoteColors[note]) // Note color background
 		} else {
 			// Default mode: show note name with finger color
-			displayText = fmt.Sprintf(" %-2s", theory.NoteNames[m.Note])
+			displayText = noteCell(props.Key.Spell(m.Note))
 			style = getFingerStyle(m.Finger, true)
 			style = style.Copy().Bold(true)
 		}
@@ -305,6 +540,117 @@ func buildActiveLayer(grid map[string]cellData, props FretboardProps) {
 	}
 }
 
+// buildBendGhostLayer marks the fret whose pitch a bend reaches, so the
+// player can hear the target first
+func buildBendGhostLayer(grid map[string]cellData, props FretboardProps) {
+	for _, item := range props.ActiveItems {
+		m := item.Marker
+		target := m.BendTargetFret()
+		if target < 0 || target > props.FretCount {
+			continue
+		}
+		key := fmt.Sprintf("%d_%d", m.StringIndex, target)
+		if cell, exists := grid[key]; exists && cell.priority >= 3 {
+			continue
+		}
+		grid[key] = cellData{
+			text:     noteCell(props.Key.Spell(m.BendTargetPitch().Note())),
+			style:    getFingerStyle(m.Finger, false).Faint(true).Italic(true),
+			priority: 3,
+		}
+	}
+}
+
+// buildTriadMapLayer lights the notes of the triad shapes on the note color
+// of each chord tone, root in bold, under the selected voicing
+func buildTriadMapLayer(grid map[string]cellData, props FretboardProps) {
+	for _, v := range props.Triads {
+		for s, f := range v.Frets {
+			if f < 0 || f > props.FretCount {
+				continue
+			}
+			note := theory.CalculateNote(props.Tuning[s], f)
+			text := noteCell(props.Key.Spell(note))
+			if props.ShowIntervals {
+				text, _ = intervalCell(props, note)
+			}
+			style := lipgloss.NewStyle().
+				Foreground(theory.CatBase).
+				Background(theory.NoteColors[note])
+			if note == v.Root {
+				style = style.Bold(true)
+			}
+			grid[fmt.Sprintf("%d_%d", s, f)] = cellData{
+				text:     text,
+				style:    style,
+				priority: 0,
+			}
+		}
+	}
+}
+
+// buildVoicingLayer draws a chord voicing in finger colors, with an × at
+// the nut for muted strings
+func buildVoicingLayer(grid map[string]cellData, props FretboardProps) {
+	v := props.Voicing
+	if v == nil {
+		return
+	}
+	for s, f := range v.Frets {
+		key := fmt.Sprintf("%d_%d", s, max(f, 0))
+		if f < 0 {
+			grid[key] = cellData{
+				text:     " × ",
+				style:    lipgloss.NewStyle().Foreground(theory.CatOverlay1).Bold(true),
+				priority: 3,
+			}
+			continue
+		}
+		note := theory.CalculateNote(props.Tuning[s], f)
+		text := noteCell(props.Key.Spell(note))
+		if props.ShowIntervals {
+			text, _ = intervalCell(props, note)
+		}
+		style := getFingerStyle(v.Fingers[s], true)
+		if note == v.Root {
+			style = style.Underline(true)
+		}
+		grid[key] = cellData{
+			text:     text,
+			style:    style,
+			priority: 3,
+		}
+	}
+}
+
+// buildQuizLayer draws the quiz question: a "?" on the fret to name, the
+// frets found so far and the cursor, on top of everything
+func buildQuizLayer(grid map[string]cellData, props FretboardProps) {
+	if props.QuizAsk != "" {
+		grid[props.QuizAsk] = cellData{
+			text:     " ? ",
+			style:    activeNoteStyle,
+			priority: 3,
+		}
+	}
+	for key, note := range props.QuizFound {
+		grid[key] = cellData{
+			text:     noteCell(props.Key.Spell(note)),
+			style:    lipgloss.NewStyle().Bold(true).Foreground(theory.CatBase).Background(theory.CatGreen),
+			priority: 3,
+		}
+	}
+	if props.QuizCursor != "" {
+		cell, ok := grid[props.QuizCursor]
+		if !ok {
+			cell.text = "[ ]"
+		}
+		cell.style = cell.style.Reverse(true).Bold(true)
+		cell.priority = 3
+		grid[props.QuizCursor] = cell
+	}
+}
+
 // formatFretWithTechnique formats fret number with technique notation inline using Unicode
 func formatFretWithTechnique(m lesson.Marker) string {
 	var result string
@@ -352,7 +698,15 @@ func renderGrid(grid map[string]cellData, props FretboardProps) string {
 	// Header: fret numbers
 	b.WriteString("      ") // 6 spaces to align with string label
 	for f := 0; f <= props.FretCount; f++ {
-		b.WriteString(lipgloss.NewStyle().Foreground(theory.CatLavender).Render(fmt.Sprintf("%-4d", f)))
+		label := fmt.Sprintf("%-4d", f)
+		if props.Capo.Active() && !props.AbsoluteFrets {
+			// Capo-relative numbering, nothing behind the capo
+			label = "    "
+			if f >= props.Capo.Fret {
+				label = fmt.Sprintf("%-4d", f-props.Capo.Fret)
+			}
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(theory.CatLavender).Render(label))
 	}
 	b.WriteString("\n")
 
@@ -368,6 +722,12 @@ func renderGrid(grid map[string]cellData, props FretboardProps) string {
 			if cell, exists := grid[key]; exists {
 				// Cell has data: render with style
 				b.WriteString(cell.style.Render(cell.text))
+			} else if props.Capo.Covers(s) && f == props.Capo.Fret {
+				// Capo bar
+				b.WriteString(capoStyle.Render("▐█▌"))
+			} else if props.Capo.Covers(s) && f < props.Capo.Fret {
+				// Frets behind the capo can't be played
+				b.WriteString(behindCapo.Render("---"))
 			} else {
 				// Empty cell: show inlay or string
 				content := renderEmptyCell(s, f)
@@ -409,6 +769,26 @@ func renderEmptyCell(stringIdx, fret int) string {
 	return "---"
 }
 
+// renderChordLine names the chord of the active notes: "Chord: Cmaj7/E (1st inversion)  also Em/..."
+func renderChordLine(props FretboardProps) string {
+	if len(props.Chords) == 0 {
+		return ""
+	}
+	best := props.Chords[0]
+	line := lipgloss.NewStyle().Foreground(theory.CatGreen).Bold(true).Render("Chord: ") +
+		lipgloss.NewStyle().Foreground(theory.CatYellow).Bold(true).Render(best.Name(props.Key)) +
+		lipgloss.NewStyle().Foreground(theory.CatSubtext1).Render(fmt.Sprintf(" (%s)", best.InversionName()))
+
+	var others []string
+	for _, c := range props.Chords[1:min(len(props.Chords), 4)] {
+		others = append(others, c.Name(props.Key))
+	}
+	if len(others) > 0 {
+		line += lipgloss.NewStyle().Foreground(theory.CatOverlay1).Render("  also " + strings.Join(others, ", "))
+	}
+	return line
+}
+
 // renderTechniqueLine renders the technique notation line below fretboard with Unicode symbols
 func renderTechniqueLine(props FretboardProps) string {
 	if len(props.ActiveItems) == 0 {
@@ -430,7 +810,8 @@ func renderTechniqueLine(props FretboardProps) string {
 		}
 		
 		var symbol string
-		var sourceFret int = m.Fret // Store source fret for ghost preview
+		var sourceFret int = displayFret(props, m.StringIndex, m.Fret) // Store source fret for ghost preview
+		target := displayFret(props, m.StringIndex, m.TechParams.TargetFret)
 		
 		switch m.Technique {
 		case "bend":
@@ -454,19 +835,19 @@ func renderTechniqueLine(props FretboardProps) string {
 		case "slide":
 			if m.TechParams.SlideType == "up" {
 				// Show: 5→7 (slide from 5 to 7)
-				symbol = fmt.Sprintf("→%d", m.TechParams.TargetFret)
+				symbol = fmt.Sprintf("→%d", target)
 			} else if m.TechParams.SlideType == "down" {
 				// Show: 7←5 (slide from 7 to 5)
-				symbol = fmt.Sprintf("←%d", m.TechParams.TargetFret)
+				symbol = fmt.Sprintf("←%d", target)
 			} else {
 				symbol = "→"
 			}
 		case "hammer":
 			// Show: 5ʰ7 (hammer from 5 to 7)
-			symbol = fmt.Sprintf("ʰ%d", m.TechParams.TargetFret)
+			symbol = fmt.Sprintf("ʰ%d", target)
 		case "pulloff":
 			// Show: 7ᵖ5 (pull from 7 to 5)
-			symbol = fmt.Sprintf("ᵖ%d", m.TechParams.TargetFret)
+			symbol = fmt.Sprintf("ᵖ%d", target)
 		case "vibrato":
 			symbol = "~"
 			sourceFret = -1 // No ghost text for vibrato
@@ -474,14 +855,15 @@ func renderTechniqueLine(props FretboardProps) string {
 			symbol = "ᵀ"
 			sourceFret = -1 // No ghost text for tap
 		case "harmonic":
-			symbol = "◊"
+			// Show the sounding note: ◊B at <7> on the high e
+			symbol = "◊" + props.Key.Spell(m.Note)
 			sourceFret = -1 // No ghost text for harmonic
 		case "pinch":
 			symbol = "*"
 			sourceFret = -1 // No ghost text for pinch
 		case "trill":
 			// Show: 5≈7 (trill between 5 and 7)
-			symbol = fmt.Sprintf("≈%d", m.TechParams.TargetFret)
+			symbol = fmt.Sprintf("≈%d", target)
 		}
 		
 		if symbol != "" {
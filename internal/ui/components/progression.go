@@ -0,0 +1,88 @@
+package components
+
+import (
+	"strings"
+
+	"guitui/internal/theory"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProgressionProps configures the chord analysis row above the fretboard
+type ProgressionProps struct {
+	Functions []theory.ChordFunction
+	Key       theory.Key
+	Current   int // Index of the chord now playing, -1 = none
+	Width     int // Available columns, 0 = unlimited
+}
+
+// progressionColors marks chords outside the key
+var progressionColors = map[string]lipgloss.Color{
+	theory.FunctionDiatonic:  theory.CatText,
+	theory.FunctionSecondary: theory.CatPeach,
+	theory.FunctionBorrowed:  theory.CatMauve,
+	theory.FunctionChromatic: theory.CatRed,
+}
+
+// RenderProgressionRow writes the lesson's chords as Roman numerals with
+// their Nashville numbers: "I 1 │ V7/ii 67 │ ii7 2-7". The current chord is
+// highlighted with its name and cadence; when the row is too wide it
+// scrolls to keep the current chord in view.
+func RenderProgressionRow(props ProgressionProps) string {
+	if len(props.Functions) == 0 {
+		return ""
+	}
+	labelStyle := lipgloss.NewStyle().Foreground(theory.CatOverlay1)
+	nashvilleStyle := lipgloss.NewStyle().Foreground(theory.CatOverlay1)
+	currentStyle := lipgloss.NewStyle().Foreground(theory.CatCrust).Background(theory.CatYellow).Bold(true)
+	sep := labelStyle.Render(" │ ")
+
+	cells := make([]string, len(props.Functions))
+	for i, f := range props.Functions {
+		if i == props.Current {
+			cells[i] = currentStyle.Render(" " + f.Numeral + " " + f.Chord.Name(props.Key) + " ")
+			continue
+		}
+		style := lipgloss.NewStyle().Foreground(progressionColors[f.Kind])
+		cells[i] = style.Render(f.Numeral) + " " + nashvilleStyle.Render(f.Nashville)
+	}
+
+	label := labelStyle.Render("Harmony: ")
+	tail := ""
+	if props.Current >= 0 && props.Current < len(props.Functions) {
+		f := props.Functions[props.Current]
+		tail = "  " + labelStyle.Render(f.Nashville)
+		if f.Kind != theory.FunctionDiatonic {
+			tail += " " + lipgloss.NewStyle().Foreground(progressionColors[f.Kind]).Render(f.Kind)
+		}
+		if f.Pattern != "" {
+			tail += " " + lipgloss.NewStyle().Foreground(theory.CatGreen).Render("← "+f.Pattern)
+		}
+	}
+
+	// Drop chords from the side away from the current one until it fits
+	first, last := 0, len(cells)
+	fits := func() bool {
+		w := lipgloss.Width(label) + lipgloss.Width(tail) + lipgloss.Width(sep)*(last-first-1)
+		for _, c := range cells[first:last] {
+			w += lipgloss.Width(c)
+		}
+		return props.Width <= 0 || w <= props.Width-2
+	}
+	for !fits() && last-first > 1 {
+		if props.Current-first > last-1-props.Current {
+			first++
+		} else {
+			last--
+		}
+	}
+
+	row := strings.Join(cells[first:last], sep)
+	if first > 0 {
+		row = labelStyle.Render("… ") + row
+	}
+	if last < len(cells) {
+		row += labelStyle.Render(" …")
+	}
+	return label + row + tail
+}
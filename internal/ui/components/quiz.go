@@ -0,0 +1,67 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"guitui/internal/theory"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// QuizStat is the note quiz record of one string and fret region
+type QuizStat struct {
+	Attempts int
+	Correct  int
+	Average  time.Duration // Of the correct answers
+}
+
+// QuizStatsProps configures the quiz statistics table
+type QuizStatsProps struct {
+	Regions []string     // Column names
+	Stats   [][]QuizStat // By string (low E first), then by region
+	Target  time.Duration
+}
+
+// RenderQuizStats writes the accuracy and average time of every string and
+// fret region, high e on top like the fretboard. Spots missed often or
+// answered slower than Target are red, good ones green.
+func RenderQuizStats(props QuizStatsProps) string {
+	labelStyle := lipgloss.NewStyle().Foreground(theory.CatOverlay1)
+
+	header := "      "
+	for _, name := range props.Regions {
+		header += fmt.Sprintf("%-11s", name)
+	}
+	lines := []string{labelStyle.Render(header)}
+
+	for s := len(props.Stats) - 1; s >= 0; s-- {
+		label := "?"
+		if s < len(stringLabels) {
+			label = stringLabels[s]
+		}
+		row := labelStyle.Render(fmt.Sprintf("  %s   ", label))
+		for _, st := range props.Stats[s] {
+			if st.Attempts == 0 {
+				row += labelStyle.Render(fmt.Sprintf("%-11s", "  ·"))
+				continue
+			}
+			accuracy := st.Correct * 100 / st.Attempts
+			color := theory.CatRed
+			switch {
+			case accuracy >= 90 && st.Average <= props.Target:
+				color = theory.CatGreen
+			case accuracy >= 70:
+				color = theory.CatYellow
+			}
+			cell := fmt.Sprintf("%3d%%    - ", accuracy)
+			if st.Correct > 0 {
+				cell = fmt.Sprintf("%3d%% %4.1fs", accuracy, st.Average.Seconds())
+			}
+			row += lipgloss.NewStyle().Foreground(color).Render(cell) + " "
+		}
+		lines = append(lines, row)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"guitui/internal/lesson"
@@ -15,6 +16,8 @@ type TechniqueDisplayProps struct {
 	CurrentStep  lesson.Step
 	CurrentIndex int
 	TotalSteps   int
+	Key          theory.Key          // Spells note names
+	Chords       []theory.ChordMatch // Names of the notes sounding, best first
 }
 
 var (
@@ -56,6 +59,21 @@ func RenderTechniqueInfo(props TechniqueDisplayProps) string {
 		props.CurrentIndex+1, props.TotalSteps))
 	lines = append(lines, title)
 	
+	// Chord name, with other readings of the same notes
+	if len(props.Chords) > 0 {
+		best := props.Chords[0]
+		lines = append(lines, "")
+		lines = append(lines, techLabelStyle.Render("Chord:"))
+		lines = append(lines, "  "+techValueStyle.Render(fmt.Sprintf("• %s (%s)", best.Name(props.Key), best.InversionName())))
+		var others []string
+		for _, c := range props.Chords[1:min(len(props.Chords), 4)] {
+			others = append(others, c.Name(props.Key))
+		}
+		if len(others) > 0 {
+			lines = append(lines, "  "+techValueStyle.Render("• also "+strings.Join(others, ", ")))
+		}
+	}
+	
 	// Collect all techniques in current step
 	techniques := make(map[lesson.TechniqueType]int)
 	pickings := make(map[lesson.PickingType]int)
@@ -79,17 +97,20 @@ func RenderTechniqueInfo(props TechniqueDisplayProps) string {
 		for tech, count := range techniques {
 			var desc string
 			// Get first marker with this technique for details
-			var params lesson.TechniqueParams
+			var marker lesson.Marker
 			for _, m := range props.CurrentStep.Markers {
 				if m.Technique == tech {
-					params = m.TechParams
+					marker = m
 					break
 				}
 			}
+			params := marker.TechParams
 			
 			switch tech {
 			case lesson.TechBend:
-				desc = fmt.Sprintf("Bend → fret %d", params.TargetFret)
+				desc = bendDescription(marker, props.Key)
+			case lesson.TechPreBend:
+				desc = "Pre-bend" + strings.TrimPrefix(bendDescription(marker, props.Key), "Bend")
 			case lesson.TechSlide:
 				if params.SlideType == "up" {
 					desc = fmt.Sprintf("Slide UP → fret %d", params.TargetFret)
@@ -111,7 +132,7 @@ func RenderTechniqueInfo(props TechniqueDisplayProps) string {
 			case lesson.TechTap:
 				desc = "Tap with right hand"
 			case lesson.TechHarmonic:
-				desc = "Natural harmonic"
+				desc = harmonicDescription(marker, props.Key)
 			case lesson.TechPinch:
 				desc = "Pinch harmonic"
 			case lesson.TechTrill:
@@ -186,7 +207,7 @@ func RenderTechniqueInfo(props TechniqueDisplayProps) string {
 	}
 	
 	// If no techniques, show basic info
-	if !hasLeftHand && len(pickings) == 0 && props.CurrentStep.PickingPattern == "" {
+	if !hasLeftHand && len(pickings) == 0 && props.CurrentStep.PickingPattern == "" && len(props.Chords) == 0 {
 		lines = append(lines, "")
 		lines = append(lines, techValueStyle.Render("No special techniques"))
 	}
@@ -194,3 +215,33 @@ func RenderTechniqueInfo(props TechniqueDisplayProps) string {
 	content := strings.Join(lines, "\n")
 	return techBoxStyle.Render(content)
 }
+
+// harmonicDescription names the harmonic with its touch point and sounding pitch
+func harmonicDescription(m lesson.Marker, key theory.Key) string {
+	node := m.TechParams.HarmonicNode
+	if node == 0 {
+		node = float64(m.Fret)
+	}
+	nodeStr := strconv.FormatFloat(node, 'f', -1, 64)
+
+	desc := fmt.Sprintf("Natural harmonic at %s", nodeStr)
+	if m.TechParams.HarmonicType == lesson.HarmonicArtificial {
+		desc = fmt.Sprintf("Artificial harmonic: fret %d, touch %s", m.Fret, nodeStr)
+	}
+	if partial := m.HarmonicPartial(); partial > 0 {
+		desc += fmt.Sprintf(" → %s (partial %d)", m.Pitch.Spell(key), partial)
+	}
+	return desc
+}
+
+// bendDescription names the bend amount, its target pitch and release
+func bendDescription(m lesson.Marker, key theory.Key) string {
+	desc := fmt.Sprintf("Bend %s → %s", m.TechParams.BendSteps, m.BendTargetPitch().Spell(key))
+	if fret := m.BendTargetFret(); fret >= 0 {
+		desc += fmt.Sprintf(" (fret %d)", fret)
+	}
+	if m.TechParams.BendRelease {
+		desc += ", release"
+	}
+	return desc
+}
@@ -0,0 +1,59 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"guitui/internal/theory"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TriadMapProps configures the legend of the triad map
+type TriadMapProps struct {
+	Groups    []theory.TriadGroup
+	Root      theory.Note
+	Quality   theory.ChordQuality
+	Key       theory.Key // Spells the chord tones
+	Inversion int        // -1 = every inversion
+}
+
+// RenderTriadMap writes the chord tones in their fretboard colors, then one
+// line per inversion with the frets its shapes start at on each string set:
+//
+//	Tones:  R C   3 E   5 G
+//	Root position  4-6: 8 │ 3-5: 0 12 │ 2-4: 8 │ 1-3: 5
+func RenderTriadMap(props TriadMapProps) string {
+	labelStyle := lipgloss.NewStyle().Foreground(theory.CatOverlay1)
+	sep := labelStyle.Render(" │ ")
+
+	tones := []string{labelStyle.Render("Tones: ")}
+	for _, interval := range props.Quality.Intervals {
+		note := theory.Note((int(props.Root) + interval) % 12)
+		style := lipgloss.NewStyle().Foreground(theory.CatBase).Background(theory.NoteColors[note])
+		if interval == 0 {
+			style = style.Bold(true)
+		}
+		tones = append(tones, style.Render(" "+theory.IntervalNames[interval]+" "+props.Key.Spell(note)+" "))
+	}
+	lines := []string{strings.Join(tones, " ")}
+
+	for inversion := 0; inversion < 3; inversion++ {
+		if props.Inversion >= 0 && props.Inversion != inversion {
+			continue
+		}
+		var cells []string
+		for _, group := range props.Groups {
+			frets := make([]string, len(group.Inversions[inversion]))
+			for i, v := range group.Inversions[inversion] {
+				frets[i] = fmt.Sprint(v.Position())
+			}
+			if len(frets) == 0 {
+				frets = []string{"-"}
+			}
+			cells = append(cells, labelStyle.Render(group.Set.Name+": ")+strings.Join(frets, " "))
+		}
+		lines = append(lines, fmt.Sprintf("%-14s ", theory.InversionName(inversion))+strings.Join(cells, sep))
+	}
+	return strings.Join(lines, "\n")
+}
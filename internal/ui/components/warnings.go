@@ -0,0 +1,80 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"guitui/internal/lesson"
+	"guitui/internal/theory"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	warnErrorStyle   = lipgloss.NewStyle().Foreground(theory.CatRed)
+	warnWarningStyle = lipgloss.NewStyle().Foreground(theory.CatYellow)
+	warnInfoStyle    = lipgloss.NewStyle().Foreground(theory.CatSubtext1).Faint(true)
+)
+
+// RenderFingeringWarnings renders fingering issues of the lesson, the ones at
+// the current beat first. At most maxLines issues are listed.
+func RenderFingeringWarnings(issues []lesson.FingeringIssue, currentBeat int, maxLines int) string {
+	if len(issues) == 0 || maxLines <= 0 {
+		return ""
+	}
+
+	// Current beat first, then the rest in beat order
+	ordered := make([]lesson.FingeringIssue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Beat == currentBeat {
+			ordered = append(ordered, issue)
+		}
+	}
+	for _, issue := range issues {
+		if issue.Beat != currentBeat {
+			ordered = append(ordered, issue)
+		}
+	}
+
+	var lines []string
+	for i, issue := range ordered {
+		if i == maxLines {
+			lines = append(lines, warnInfoStyle.Render(fmt.Sprintf("  … %d more", len(ordered)-maxLines)))
+			break
+		}
+
+		style := warnInfoStyle
+		icon := "ℹ"
+		switch issue.Severity {
+		case lesson.SeverityError:
+			style, icon = warnErrorStyle, "✗"
+		case lesson.SeverityWarning:
+			style, icon = warnWarningStyle, "⚠"
+		}
+
+		text := fmt.Sprintf("%s %s", icon, issue)
+		if issue.Beat == currentBeat {
+			style = style.Bold(true)
+		}
+		lines = append(lines, style.Render(text))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RenderDefinitionErrors renders the entries of the user definitions file
+// that were rejected at startup. At most maxLines errors are listed.
+func RenderDefinitionErrors(errs []error, maxLines int) string {
+	if len(errs) == 0 || maxLines <= 0 {
+		return ""
+	}
+	var lines []string
+	for i, err := range errs {
+		if i == maxLines {
+			lines = append(lines, warnInfoStyle.Render(fmt.Sprintf("  … %d more", len(errs)-maxLines)))
+			break
+		}
+		lines = append(lines, warnErrorStyle.Render("✗ "+err.Error()))
+	}
+	return strings.Join(lines, "\n")
+}
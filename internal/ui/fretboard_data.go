@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"guitui/internal/lesson"
+	"guitui/internal/theory"
 	"guitui/internal/ui/components"
 )
 
@@ -35,7 +36,8 @@ func NewFretboardDataBuilder(l *lesson.Lesson, beat int) *FretboardDataBuilder {
 }
 
 // BuildActiveItems returns active notes for current beat
-// Includes notes from previous steps that are still holding (duration > 1)
+// Includes notes from previous steps that are still holding (duration > 1).
+// Marker frets are real frets on the neck (capo applied).
 func (b *FretboardDataBuilder) BuildActiveItems() []components.ActiveItem {
 	if b.lesson == nil || len(b.lesson.Steps) == 0 {
 		return []components.ActiveItem{}
@@ -62,7 +64,7 @@ func (b *FretboardDataBuilder) BuildActiveItems() []components.ActiveItem {
 			// Check if this marker is active at current beat
 			if b.currentBeat >= stepBeat && b.currentBeat <= stepEndBeat {
 				activeItems = append(activeItems, components.ActiveItem{
-					Marker: marker,
+					Marker: b.lesson.Capo.AbsoluteMarker(marker),
 					Order:  i + 1, // 1-based step order
 				})
 			}
@@ -72,6 +74,39 @@ func (b *FretboardDataBuilder) BuildActiveItems() []components.ActiveItem {
 	return activeItems
 }
 
+// CurrentStep returns the step playing at the current beat and its index,
+// -1 before the first step
+func (b *FretboardDataBuilder) CurrentStep() (lesson.Step, int) {
+	if b.lesson == nil || b.currentStep < 0 {
+		return lesson.Step{}, -1
+	}
+	return b.lesson.Steps[b.currentStep], b.currentStep
+}
+
+// BuildChord names the notes sounding at the current beat, held notes
+// included, best match first. Muted strings do not sound. Empty for single
+// notes.
+func (b *FretboardDataBuilder) BuildChord() []theory.ChordMatch {
+	var sounding []lesson.Marker
+	for _, item := range b.BuildActiveItems() {
+		if item.Marker.Fret >= 0 {
+			sounding = append(sounding, item.Marker)
+		}
+	}
+	if len(sounding) < 2 {
+		return nil
+	}
+	notes := make([]theory.Note, 0, len(sounding))
+	bass := sounding[0]
+	for _, m := range sounding {
+		notes = append(notes, m.Note)
+		if m.Pitch < bass.Pitch {
+			bass = m
+		}
+	}
+	return theory.IdentifyChord(notes, bass.Note)
+}
+
 // BuildUpcomingMarkers returns upcoming notes (lookahead)
 func (b *FretboardDataBuilder) BuildUpcomingMarkers(lookAhead int) map[string]components.UpcomingItem {
 	upcoming := make(map[string]components.UpcomingItem)
@@ -85,7 +120,7 @@ func (b *FretboardDataBuilder) BuildUpcomingMarkers(lookAhead int) map[string]co
 		step := b.lesson.Steps[nextIdx]
 
 		for _, marker := range step.Markers {
-			key := fmt.Sprintf("%d_%d", marker.StringIndex, marker.Fret)
+			key := b.gridKey(marker)
 			// Only save first occurrence
 			if _, exists := upcoming[key]; !exists {
 				upcoming[key] = components.UpcomingItem{
@@ -109,12 +144,13 @@ func (b *FretboardDataBuilder) BuildScaleSequence() map[string]components.Sequen
 
 	for i, step := range b.lesson.Steps {
 		for _, marker := range step.Markers {
-			key := fmt.Sprintf("%d_%d", marker.StringIndex, marker.Fret)
+			key := b.gridKey(marker)
 			// Only save first occurrence
 			if _, exists := scaleSeq[key]; !exists {
 				scaleSeq[key] = components.SequenceItem{
 					Order:  i + 1, // 1-based
 					Finger: marker.Finger,
+					Note:   marker.Note,
 				}
 			}
 		}
@@ -123,6 +159,12 @@ func (b *FretboardDataBuilder) BuildScaleSequence() map[string]components.Sequen
 	return scaleSeq
 }
 
+// gridKey returns the fretboard cell of a marker at its real fret (capo applied)
+func (b *FretboardDataBuilder) gridKey(marker lesson.Marker) string {
+	fret := b.lesson.Capo.AbsoluteFret(marker.StringIndex, marker.Fret)
+	return fmt.Sprintf("%d_%d", marker.StringIndex, fret)
+}
+
 // BuildAll builds all fretboard data at once
 func (b *FretboardDataBuilder) BuildAll(showUpcoming bool, lookAhead int) (
 	activeItems []components.ActiveItem,
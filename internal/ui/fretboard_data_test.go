@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"testing"
+
+	"guitui/internal/lesson"
+	"guitui/internal/theory"
+)
+
+func TestBuildChordSkipsMutedStrings(t *testing.T) {
+	// Am as x02210: the muted low E is parsed as Fret -1, Note C, Pitch 0
+	frets := []int{-1, 0, 2, 2, 1, 0}
+	var markers []lesson.Marker
+	for s, f := range frets {
+		markers = append(markers, lesson.Marker{StringIndex: s, Fret: f})
+	}
+	l := lesson.Lesson{Steps: []lesson.Step{{Beat: 1, Markers: markers}}}
+	l.RecalculateNotes()
+
+	chords := NewFretboardDataBuilder(&l, 1).BuildChord()
+	if len(chords) == 0 {
+		t.Fatal("no chord for x02210")
+	}
+	key := theory.DefaultKey(theory.A, true)
+	if name := chords[0].Name(key); name != "Am" {
+		t.Errorf("x02210 named %q, want Am", name)
+	}
+	if inversion := chords[0].Inversion(); inversion != 0 {
+		t.Errorf("x02210 in inversion %d, want root position", inversion)
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,23 +33,59 @@ type MetroBeatMsg struct {
 // Wrapper cho list item
 type item struct {
 	lesson lesson.Lesson
+	index  lesson.LessonIndex
+}
+
+func newItem(l lesson.Lesson) item {
+	return item{lesson: l, index: lesson.BuildIndex(l)}
 }
 
 func (i item) Title() string { return i.lesson.Title }
 func (i item) Description() string {
-	return fmt.Sprintf("Key: %s | BPM: %d", i.lesson.KeyStr, i.lesson.BPM)
+	key := i.lesson.KeyStr
+	if key == "" {
+		key = "-"
+	}
+	return fmt.Sprintf("Key: %s | BPM: %d | %s", key, i.lesson.BPM, i.index.Summary())
+}
+func (i item) FilterValue() string {
+	return i.lesson.Title + " " + strings.Join(i.index.Tags(), " ")
+}
+
+// lessonFilter matches list items against a lesson.Query instead of fuzzy text,
+// so "pinch harmonics sweep" finds lessons that use both techniques
+func lessonFilter(items []list.Item) list.FilterFunc {
+	return func(term string, targets []string) []list.Rank {
+		q := lesson.ParseQuery(term)
+		var ranks []list.Rank
+		for i := range targets {
+			it, ok := items[i].(item)
+			if !ok {
+				continue
+			}
+			if q.Match(it.lesson, it.index) {
+				ranks = append(ranks, list.Rank{Index: i})
+			}
+		}
+		return ranks
+	}
 }
-func (i item) FilterValue() string { return i.lesson.Title }
 
 type Model struct {
 	// Logic Data
-	lessons       []lesson.Lesson
-	currentLesson lesson.Lesson
-	currentBeat   int // Current beat number (1-based)
+	lessons          []lesson.Lesson
+	baseLesson       lesson.Lesson // Selected lesson before transposition
+	currentLesson    lesson.Lesson // What is displayed and played
+	currentBeat      int           // Current beat number (1-based)
+	lessonIssues     []lesson.FingeringIssue
+	progression      []lesson.ChordEvent // Chords of currentLesson, for the harmony row
+	definitionErrors []error             // Rejected entries of the user definitions file
+	transpose        int                 // Semitones applied to baseLesson - Phím [ ]
 
 	// UI State
 	list          list.Model
 	tuning        []theory.Note
+	tuningIndex   int // Index into theory.TuningOrder - Phím X/x
 	width, height int
 	fretCount     int
 
@@ -58,6 +95,41 @@ type Model struct {
 	showScaleShape bool // Sequence/Shape Mode - Phím S
 	showUpcoming   bool // Toggle upcoming markers - Phím U
 	showHelp       bool // Toggle full help text - Phím ?
+	absoluteFrets  bool // Fret numbers from the nut instead of the capo - Phím C
+	showOctaves    bool // Octave numbers on tab mode note names - Phím O
+	showIntervals  bool // Interval labels against the key - Phím I
+
+	// Scale overlay - Phím V (bật/tắt), N/n (đổi scale), R/r (đổi root)
+	showScaleOverlay bool
+	overlayScale     int // Index into theory.ScaleOrder
+	overlayRoot      theory.Note
+
+	// Position browsing - Phím P/p (vị trí trước/sau), Y (CAGED / 3NPS)
+	position      int // 1..N, 0 = off
+	positionThree bool
+
+	// Chord finder - Phím Shift+C
+	chordFinder chordFinder
+
+	// Interactive circle of fifths - Phím K
+	circle circleNav
+
+	// Note-finding quiz - Phím Shift+Q
+	quiz quiz
+
+	// Generated arpeggios - Phím E/e (vị trí trước/sau)
+	arpRoot    theory.Note
+	arpQuality int // Index into theory.ChordQualities
+	arpeggio   int // Index into the generated lessons, -1 = none loaded
+
+	// Generated scale sequences - Phím T/t (mẫu trước/sau)
+	seqScale    string
+	seqRoot     theory.Note
+	seqPosition theory.Position
+	sequence    int // Index into the generated lessons, -1 = none loaded
+
+	// Generated licks (call and response) - Phím W/w (lick trước/mới)
+	lickSeed int64
 
 	// Metronome State
 	metronomeActive    bool
@@ -69,17 +141,21 @@ type Model struct {
 }
 
 func NewModel() Model {
+	// 0. User scales, tunings and positions, before anything lists them
+	definitionErrors := theory.LoadDefinitions(theory.DefinitionsFile)
+
 	// 1. Load Data from both JSON and TAB files
 	loadedLessons, err := lesson.LoadLessonsFromMultipleSources("lessons.json", "lessons_tab")
 	if err != nil {
 		fmt.Println("Lỗi load lessons:", err)
 		loadedLessons = []lesson.Lesson{}
 	}
+	loadedLessons = append(loadedLessons, lesson.GeneratedExercises()...)
 
 	// 2. Setup List Component
 	var items []list.Item
 	for _, l := range loadedLessons {
-		items = append(items, item{lesson: l})
+		items = append(items, newItem(l))
 	}
 
 	// Custom Delegate hiển thị list kiểu Catppuccin
@@ -94,7 +170,8 @@ func NewModel() Model {
 	l := list.New(items, delegate, 0, 0)
 	l.Title = "GUITAR LESSONS"
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.SetFilteringEnabled(true)
+	l.Filter = lessonFilter(items)
 	l.SetShowHelp(false) // Disable built-in help, we'll add custom help
 	l.Styles.Title = lipgloss.NewStyle().Foreground(theory.CatMauve).Bold(true).Padding(0, 1)
 
@@ -118,7 +195,17 @@ func NewModel() Model {
 
 	return Model{
 		lessons:            loadedLessons,
+		baseLesson:         firstLesson,
 		currentLesson:      firstLesson,
+		lessonIssues:       lesson.CheckFingering(firstLesson),
+		definitionErrors:   definitionErrors,
+		progression:        firstLesson.ChordProgression(),
+		overlayRoot:        firstLesson.ActualKey,
+		overlayScale:       defaultOverlayScale(firstLesson),
+		chordFinder:        chordFinder{inversion: -1, found: newChordSearches()},
+		arpeggio:           -1,
+		sequence:           -1,
+		lickSeed:           time.Now().UnixNano() % 10000,
 		list:               l,
 		tuning:             theory.StandardTuning,
 		fretCount:          12,
@@ -171,6 +258,142 @@ func (m Model) getActiveStepIndex() int {
 	return activeIdx
 }
 
+// loadLesson makes l the lesson on screen, from beat 1 at its own tempo
+func (m *Model) loadLesson(l lesson.Lesson) {
+	m.baseLesson = l
+	m.transpose = 0
+	m.refreshLesson()
+	m.overlayRoot = m.currentLesson.ActualKey
+	m.overlayScale = defaultOverlayScale(m.currentLesson)
+	m.currentBeat = 1 // Start at beat 1
+	// Set BPM from lesson
+	if m.currentLesson.BPM > 0 {
+		m.metroBPM = m.currentLesson.BPM
+		if m.metroPlayer != nil {
+			m.metroPlayer.SetBPM(m.metroBPM)
+		}
+	}
+	// Don't auto-start - user will press Space to play
+}
+
+// nextArpeggio loads the next (or previous) generated arpeggio lesson:
+// every shape and position of the chord finder's chord, else of the
+// lesson's tonic triad. Pressing again steps through the same chord.
+func (m *Model) nextArpeggio(back bool) {
+	m.sequence = -1
+	switch {
+	case m.chordFinder.active:
+		m.arpRoot, m.arpQuality = m.chordFinder.root, m.chordFinder.quality
+		m.arpeggio = -1
+		m.chordFinder.active = false
+	case m.arpeggio < 0:
+		m.arpRoot, m.arpQuality = m.currentLesson.ActualKey, 0
+		if m.currentLesson.Key().Minor {
+			m.arpQuality = 1
+		}
+	}
+
+	var lessons []lesson.Lesson
+	for _, shape := range theory.ArpeggioShapes {
+		lessons = append(lessons, lesson.ArpeggioLessons(m.arpRoot, theory.ChordQualities[m.arpQuality], shape)...)
+	}
+	if len(lessons) == 0 {
+		return
+	}
+	switch {
+	case m.arpeggio < 0 && back:
+		m.arpeggio = len(lessons) - 1
+	case m.arpeggio < 0:
+		m.arpeggio = 0
+	case back:
+		m.arpeggio = (m.arpeggio + len(lessons) - 1) % len(lessons)
+	default:
+		m.arpeggio = (m.arpeggio + 1) % len(lessons)
+	}
+	m.loadLesson(lessons[m.arpeggio])
+}
+
+// nextSequence loads the next (or previous) scale sequence lesson over the
+// overlay scale's current position (the first when none is shown). Pressing
+// again steps through the patterns of the same position.
+func (m *Model) nextSequence(back bool) {
+	m.arpeggio = -1
+	if m.sequence < 0 {
+		positions := m.lessonPositions()
+		if len(positions) == 0 {
+			return
+		}
+		m.seqScale = theory.ScaleOrder[m.overlayScale]
+		m.seqRoot = m.overlayRoot
+		m.seqPosition = positions[min(max(m.position-1, 0), len(positions)-1)]
+	}
+
+	lessons := lesson.SequenceLessons(m.seqScale, m.seqRoot, m.seqPosition)
+	switch {
+	case m.sequence < 0 && back:
+		m.sequence = len(lessons) - 1
+	case m.sequence < 0:
+		m.sequence = 0
+	case back:
+		m.sequence = (m.sequence + len(lessons) - 1) % len(lessons)
+	default:
+		m.sequence = (m.sequence + 1) % len(lessons)
+	}
+	m.loadLesson(lessons[m.sequence])
+	m.setOverlay(m.seqScale, m.seqRoot)
+}
+
+// nextLick generates a new lick (or the previous seed's) in the overlay
+// scale's current position, the first when none is shown, and shows the
+// position so the answer can be played in it
+func (m *Model) nextLick(back bool) {
+	positions := m.lessonPositions()
+	if len(positions) == 0 {
+		return
+	}
+	m.arpeggio, m.sequence = -1, -1
+	if back {
+		m.lickSeed = max(m.lickSeed-1, 0)
+	} else {
+		m.lickSeed++
+	}
+
+	scale, root := theory.ScaleOrder[m.overlayScale], m.overlayRoot
+	m.position = min(max(m.position, 1), len(positions))
+	m.loadLesson(lesson.GenerateLick(lesson.LickConfig{
+		Scale:      scale,
+		Root:       root,
+		Position:   positions[m.position-1],
+		Seed:       m.lickSeed,
+		Techniques: lesson.DefaultTechniqueChance,
+	}))
+	m.setOverlay(scale, root)
+	m.showScaleOverlay = true
+}
+
+// setOverlay puts the scale overlay back on a generated lesson's scale,
+// which loadLesson replaced with the lesson key's default
+func (m *Model) setOverlay(scale string, root theory.Note) {
+	m.overlayRoot = root
+	for i, name := range theory.ScaleOrder {
+		if name == scale {
+			m.overlayScale = i
+		}
+	}
+}
+
+// refreshLesson rebuilds the displayed lesson from the selected one
+// and the current transposition
+func (m *Model) refreshLesson() {
+	// Keep within an octave either way
+	if m.transpose > 11 || m.transpose < -11 {
+		m.transpose %= 12
+	}
+	m.currentLesson = m.baseLesson.Transpose(m.transpose)
+	m.lessonIssues = lesson.CheckFingering(m.currentLesson)
+	m.progression = m.currentLesson.ChordProgression()
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
@@ -181,6 +404,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// While typing a filter query, keys belong to the list only
+		if m.list.FilterState() == list.Filtering {
+			m.list, cmd = m.list.Update(msg)
+			return m, cmd
+		}
+
+		// Quiz, chord finder and circle keys take over while they are open
+		if m.quiz.active && m.quiz.handleKey(msg.String(), m.tuning, m.fretCount, time.Now()) {
+			return m, nil
+		}
+		if m.chordFinder.active && m.chordFinder.handleKey(msg.String()) {
+			return m, nil
+		}
+		if m.circle.active && m.circle.handleKey(msg.String()) {
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
@@ -191,6 +431,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.fretCount != 24 {
 				m.fretCount = 12
 			}
+			if m.quiz.active {
+				m.quiz.fit(m.tuning, m.fretCount, time.Now())
+			}
 
 		case "tab":
 			m.showAll = !m.showAll
@@ -317,18 +560,110 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter": // Chọn bài
 			if selectedItem, ok := m.list.SelectedItem().(item); ok {
-				m.currentLesson = selectedItem.lesson
-				m.currentBeat = 1 // Start at beat 1
-				// Set BPM from lesson
-				if m.currentLesson.BPM > 0 {
-					m.metroBPM = m.currentLesson.BPM
-					if m.metroPlayer != nil {
-						m.metroPlayer.SetBPM(m.metroBPM)
-					}
-				}
-				// Don't auto-start - user will press Space to play
+				m.arpeggio, m.sequence = -1, -1
+				m.loadLesson(selectedItem.lesson)
+			}
+
+		case "e", "E": // Next / previous generated arpeggio of the chord
+			m.nextArpeggio(msg.String() == "E")
+
+		case "t", "T": // Next / previous sequence over the overlay position
+			m.nextSequence(msg.String() == "T")
+
+		case "w", "W": // New lick in the overlay position (W: previous seed)
+			m.nextLick(msg.String() == "W")
+
+		case "x", "X": // Next / previous tuning (lessons are only shown in standard)
+			step := 1
+			if msg.String() == "X" {
+				step = len(theory.TuningOrder) - 1
+			}
+			m.tuningIndex = (m.tuningIndex + step) % len(theory.TuningOrder)
+			m.tuning = theory.Tunings[theory.TuningOrder[m.tuningIndex]]
+			m.position = min(m.position, len(m.overlayPositions()))
+
+		case "a", "A": // Auto-assign fingers to notes without (fN)
+			fingered := m.baseLesson.Clone()
+			if lesson.AssignFingers(&fingered) > 0 {
+				m.baseLesson = fingered
+				m.refreshLesson()
+			}
+
+		case "[": // Transpose down a semitone
+			m.transpose--
+			m.refreshLesson()
+			m.overlayRoot = m.currentLesson.ActualKey
+
+		case "]": // Transpose up a semitone
+			m.transpose++
+			m.refreshLesson()
+			m.overlayRoot = m.currentLesson.ActualKey
+
+		case "c": // Toggle capo-relative / absolute fret numbers
+			m.absoluteFrets = !m.absoluteFrets
+
+		case "K": // Open the circle of fifths on the lesson key
+			m.chordFinder.active = false
+			m.quiz.active = false
+			m.circle.open(m.currentLesson.Key())
+
+		case "C": // Open the chord finder on the lesson key
+			m.circle.active = false
+			m.quiz.active = false
+			m.chordFinder.active = true
+			m.chordFinder.root = m.currentLesson.ActualKey
+			m.chordFinder.quality = 0
+			if m.currentLesson.Key().Minor {
+				m.chordFinder.quality = 1
+			}
+			m.chordFinder.voicing = 0
+
+		case "Q": // Open the note quiz
+			m.chordFinder.active = false
+			m.circle.active = false
+			m.quiz.open(m.currentLesson.Key(), m.tuning, m.fretCount, time.Now())
+
+		case "o", "O": // Toggle octave numbers in tab mode
+			m.showOctaves = !m.showOctaves
+
+		case "i", "I": // Toggle interval labels
+			m.showIntervals = !m.showIntervals
+
+		case "v", "V": // Toggle scale overlay
+			m.showScaleOverlay = !m.showScaleOverlay
+
+		case "n", "N": // Next / previous overlay scale
+			step := 1
+			if msg.String() == "N" {
+				step = len(theory.ScaleOrder) - 1
+			}
+			m.overlayScale = (m.overlayScale + step) % len(theory.ScaleOrder)
+			m.showScaleOverlay = true
+			m.position = min(m.position, len(m.overlayPositions()))
+
+		case "r", "R": // Overlay root up / down a semitone
+			step := 1
+			if msg.String() == "R" {
+				step = 11
+			}
+			m.overlayRoot = theory.Note((int(m.overlayRoot) + step) % 12)
+			m.showScaleOverlay = true
+
+		case "p", "P": // Next / previous position of the overlay scale, then off
+			count := len(m.overlayPositions()) + 1
+			step := 1
+			if msg.String() == "P" {
+				step = count - 1
+			}
+			m.position = (m.position + step) % count
+			if m.position > 0 {
+				m.showScaleOverlay = true
 			}
 
+		case "y", "Y": // Switch CAGED / 3NPS positions
+			m.positionThree = !m.positionThree
+			m.position = min(m.position, len(m.overlayPositions()))
+
 		case "u", "U": // Toggle upcoming markers
 			m.showUpcoming = !m.showUpcoming
 
@@ -395,6 +730,14 @@ func (m Model) View() string {
 
 	// Only build what we need based on display modes
 	activeItems = builder.BuildActiveItems()
+	chords := builder.BuildChord()
+
+	// Interval labels follow the chord being played, else the key
+	key := m.currentLesson.Key()
+	harmony := theory.KeyHarmony(key)
+	if len(chords) > 0 {
+		harmony = chords[0].Harmony(key)
+	}
 
 	if m.showUpcoming {
 		upcoming = builder.BuildUpcomingMarkers(3)
@@ -402,7 +745,7 @@ func (m Model) View() string {
 		upcoming = make(map[string]components.UpcomingItem)
 	}
 
-	if m.showScaleShape || m.showFingers || m.showAll {
+	if m.showScaleShape || m.showFingers || m.showAll || m.showIntervals {
 		// Need scale sequence for these modes
 		scaleSequence = builder.BuildScaleSequence()
 	} else {
@@ -416,14 +759,76 @@ func (m Model) View() string {
 		Tuning:          m.tuning,
 		ShowAll:         m.showAll,
 		FretCount:       m.fretCount,
+		Key:             key,
+		Capo:            m.currentLesson.Capo,
+		AbsoluteFrets:   m.absoluteFrets,
+		ShowOctaves:     m.showOctaves,
+		ShowIntervals:   m.showIntervals,
+		Harmony:         harmony,
+		Chords:          chords,
 		ShowFingers:     m.showFingers,
 		ShowScaleShape:  m.showScaleShape,
 	}
+	if !m.lessonFitsTuning() {
+		// The lesson's frets would sound other notes on these strings
+		fretProps.ActiveItems = nil
+		fretProps.UpcomingMarkers = make(map[string]components.UpcomingItem)
+		fretProps.ScaleSequence = make(map[string]components.SequenceItem)
+		fretProps.Chords = nil
+		fretProps.Capo = lesson.Capo{}
+	}
+	if m.showScaleOverlay {
+		fretProps.OverlayScale = theory.ScaleOrder[m.overlayScale]
+		fretProps.OverlayRoot = m.overlayRoot
+		if positions := m.overlayPositions(); m.position > 0 && m.position <= len(positions) {
+			fretProps.Position = &positions[m.position-1]
+		}
+	}
+
+	// Chord finder: the voicing replaces the lesson on the fretboard
+	var voicing *theory.Voicing
+	var voicingCount int
+	if m.chordFinder.active {
+		voicing, voicingCount = m.chordFinder.current(m.tuning, m.fretCount)
+		fretProps.Voicing = voicing
+		fretProps.ActiveItems = nil
+		fretProps.UpcomingMarkers = make(map[string]components.UpcomingItem)
+		fretProps.ScaleSequence = make(map[string]components.SequenceItem)
+		fretProps.Chords = nil
+		fretProps.Capo = lesson.Capo{} // Voicings use frets from the nut
+		fretProps.Key = m.chordFinder.key()
+		fretProps.Harmony = m.chordFinder.harmony()
+		if m.chordFinder.triadMap {
+			fretProps.Triads = m.chordFinder.triads(m.tuning, m.fretCount)
+		}
+	}
+
+	// Circle of fifths: spell by the working key, light the selected chord
+	if m.circle.active {
+		fretProps.Key = m.circle.key
+		fretProps.Harmony = m.circle.harmony()
+		if chord, ok := m.circle.chord(); ok {
+			fretProps.ChordTones = chord.Tones
+		}
+	}
+
+	// Quiz: only the question on a bare neck, frets from the nut
+	if m.quiz.active {
+		fretProps = components.FretboardProps{
+			Tuning:    m.tuning,
+			FretCount: m.fretCount,
+		}
+		m.quiz.props(&fretProps)
+	}
 
 	// --- 2. RENDER COMPONENTS ---
 
 	// Top Section: Circle + List
-	rawCircle := strings.TrimSuffix(components.RenderCircle(m.currentLesson.ActualKey), "\n")
+	circleProps := components.CircleProps{Key: m.currentLesson.Key()}
+	if m.circle.active {
+		circleProps = components.CircleProps{Key: m.circle.key, Mode: m.circle.mode, Focused: true}
+	}
+	rawCircle := strings.TrimSuffix(components.RenderCircle(circleProps), "\n")
 	circleBox := lipgloss.NewStyle().
 		Width(circleWidth).
 		Height(circleHeight).
@@ -444,14 +849,45 @@ func (m Model) View() string {
 		if m.metronomeActive {
 			playStatus = "Pause"
 		}
-		line1 := fmt.Sprintf("[Space] %s  [M] Metro  [H] Fing(%s)  [S] Seq(%s)",
-			playStatus, status(m.showFingers), status(m.showScaleShape))
-		line2 := fmt.Sprintf("[Tab] Note(%s)  [U] Upc(%s)  [F] Fret(%d)  [?] less",
-			status(m.showAll), status(m.showUpcoming), m.fretCount)
-		helpText = line1 + "\n" + line2
+		entries := []string{
+			fmt.Sprintf("[Space] %s", playStatus),
+			"[M] Metro",
+			fmt.Sprintf("[H] Fing(%s)", status(m.showFingers)),
+			fmt.Sprintf("[S] Seq(%s)", status(m.showScaleShape)),
+			fmt.Sprintf("[Tab] Note(%s)", status(m.showAll)),
+			fmt.Sprintf("[O] Oct(%s)", status(m.showOctaves)),
+			fmt.Sprintf("[I] Intv(%s)", status(m.showIntervals)),
+			fmt.Sprintf("[V] Scale(%s)", status(m.showScaleOverlay)),
+			"[N/n] Scale±",
+			"[R/r] Root±",
+			"[P/p] Pos±",
+			fmt.Sprintf("[Y] %s", m.positionSystem()),
+			fmt.Sprintf("[U] Upc(%s)", status(m.showUpcoming)),
+			fmt.Sprintf("[F] Fret(%d)", m.fretCount),
+			"[A] Auto-fing",
+			fmt.Sprintf("[ ] Transp(%+d)", m.transpose),
+			fmt.Sprintf("[C] Abs fret(%s)", status(m.absoluteFrets)),
+			"[Shift+C] Chords",
+			"[Shift+K] Circle",
+			"[E/e] Arpeggio±",
+			"[T/t] Sequence±",
+			"[W/w] Lick±",
+			"[X/x] Tuning±",
+			"[Shift+Q] Quiz",
+			"[?] less",
+		}
+		helpText = wrapHelp(entries, m.width-circleWidth-4)
+	} else if m.quiz.active {
+		helpText = quizHelp
+	} else if m.chordFinder.active && m.chordFinder.triadMap {
+		helpText = triadMapHelp
+	} else if m.chordFinder.active {
+		helpText = chordFinderHelp
+	} else if m.circle.active {
+		helpText = circleNavHelp
 	} else {
 		// Short help
-		helpText = "↑/k up • ↓/j down • q quit • ? more"
+		helpText = "↑/k up • ↓/j down • / filter • q quit • ? more"
 	}
 	helpView := lipgloss.NewStyle().
 		Foreground(theory.CatSubtext1).
@@ -494,17 +930,125 @@ func (m Model) View() string {
 		metroDisplay = components.RenderMetronome(currentBeat, totalBeats, m.metroBPM)
 	}
 
+	// Technique panel: chord, techniques and picking of the current step,
+	// beside the metronome while a lesson is shown
+	var techPanel string
+	if step, index := builder.CurrentStep(); index >= 0 && !m.chordFinder.active && !m.circle.active && !m.quiz.active {
+		techPanel = components.RenderTechniqueInfo(components.TechniqueDisplayProps{
+			CurrentStep:  step,
+			CurrentIndex: index,
+			TotalSteps:   len(m.currentLesson.Steps),
+			Key:          key,
+			Chords:       chords,
+		})
+	}
+
 	// Info Bar
+	info := fmt.Sprintf("PLAYING: %s (Beat %d/%d)", m.currentLesson.Title, m.currentBeat, m.getTotalBeats())
+	if m.transpose != 0 {
+		keyName := m.currentLesson.KeyStr
+		if keyName == "" {
+			keyName = m.currentLesson.Key().String()
+		}
+		info += fmt.Sprintf(" • Key %s (%+d)", keyName, m.transpose)
+	}
+	if guess := m.currentLesson.DetectedKey; guess != nil {
+		info += fmt.Sprintf(" • Detected %s", guess)
+	}
+	if response := m.currentLesson.ResponseBeat; response > 0 {
+		if m.currentBeat >= response {
+			info += " • YOUR TURN"
+		} else {
+			info += " • Call"
+		}
+	}
+	if len(chords) > 0 {
+		info += fmt.Sprintf(" • Chord %s", chords[0].Name(key))
+	}
+	if m.showScaleOverlay {
+		scale := theory.ScaleOrder[m.overlayScale]
+		root := theory.ScaleKey(m.overlayRoot, scale).Tonic
+		info += fmt.Sprintf(" • Scale: %s %s", root, theory.ScaleDisplayName(scale))
+		if m.position > 0 {
+			info += fmt.Sprintf(" • %s %d/%d", m.positionSystem(), m.position, len(m.overlayPositions()))
+		}
+	}
+	if m.tuningIndex > 0 {
+		info += fmt.Sprintf(" • Tuning %s", theory.TuningOrder[m.tuningIndex])
+		if !m.lessonFitsTuning() {
+			info += " (lesson hidden: written in standard tuning)"
+		}
+	}
+	if capo := m.currentLesson.Capo; capo.Active() {
+		info += fmt.Sprintf(" • Capo %s", capo)
+	}
+	if m.chordFinder.active {
+		info = m.chordFinder.info(voicing, voicingCount)
+	}
+	if m.circle.active {
+		info = m.circle.info()
+	}
+	if m.quiz.active {
+		info = m.quiz.info(m.tuning, m.fretCount)
+	}
 	infoBar := lipgloss.NewStyle().
 		Foreground(theory.CatSky).Bold(true).
-		Render(fmt.Sprintf("PLAYING: %s (Beat %d/%d)", m.currentLesson.Title, m.currentBeat, m.getTotalBeats()))
+		Render(info)
+
+	// Lesson detail: fingering warnings (errors at the current beat first)
+	warnings := components.RenderFingeringWarnings(m.lessonIssues, m.currentBeat, 3)
 
 	// Build bottom section (fretboard + metronome bar)
-	bottomSection := lipgloss.JoinVertical(lipgloss.Left,
-		lipgloss.NewStyle().Padding(0, 1).Render(infoBar),
+	bottomParts := []string{lipgloss.NewStyle().Padding(0, 1).Render(infoBar)}
+	if m.circle.active {
+		row := components.RenderDiatonicRow(m.circle.chords(), m.circle.selected)
+		bottomParts = append(bottomParts, lipgloss.NewStyle().Padding(0, 1).Render(row))
+	}
+	if m.quiz.active {
+		table := components.RenderQuizStats(m.quiz.statsProps(len(m.tuning)))
+		bottomParts = append(bottomParts, lipgloss.NewStyle().Padding(0, 1).Render(table))
+	}
+	if m.chordFinder.active && m.chordFinder.triadMap {
+		legend := components.RenderTriadMap(components.TriadMapProps{
+			Groups:    m.chordFinder.triadGroups(m.tuning, m.fretCount),
+			Root:      m.chordFinder.root,
+			Quality:   theory.ChordQualities[m.chordFinder.quality],
+			Key:       m.chordFinder.key(),
+			Inversion: m.chordFinder.inversion,
+		})
+		bottomParts = append(bottomParts, lipgloss.NewStyle().Padding(0, 1).Render(legend))
+	}
+	if warnings != "" && !m.quiz.active {
+		bottomParts = append(bottomParts, lipgloss.NewStyle().Padding(0, 1).Render(warnings))
+	}
+	if errs := components.RenderDefinitionErrors(m.definitionErrors, 2); errs != "" {
+		bottomParts = append(bottomParts, lipgloss.NewStyle().Padding(0, 1).Render(errs))
+	}
+	// Harmony row: the lesson's chords as numerals, the current one lit
+	if len(m.progression) > 0 && !m.chordFinder.active && !m.circle.active && !m.quiz.active {
+		current := -1
+		for i, e := range m.progression {
+			if e.Beat <= m.currentBeat {
+				current = i
+			}
+		}
+		row := components.RenderProgressionRow(components.ProgressionProps{
+			Functions: theory.AnalyzeProgression(lesson.Chords(m.progression), key),
+			Key:       key,
+			Current:   current,
+			Width:     m.width,
+		})
+		bottomParts = append(bottomParts, lipgloss.NewStyle().Padding(0, 1).Render(row))
+	}
+	metroRow := lipgloss.NewStyle().PaddingLeft(2).Render(metroDisplay)
+	if techPanel != "" {
+		metroRow = lipgloss.JoinHorizontal(lipgloss.Top, metroRow, "  ", techPanel)
+	}
+	bottomParts = append(bottomParts,
 		lipgloss.NewStyle().Render(fretboardView),
-		lipgloss.NewStyle().PaddingLeft(2).Render(metroDisplay),
+		metroRow,
 	)
+	bottomSection := lipgloss.JoinVertical(lipgloss.Left, bottomParts...)
 
 	mainView := lipgloss.JoinVertical(lipgloss.Left, topContainer, bottomSection)
 
@@ -519,6 +1063,69 @@ func (m Model) View() string {
 	return mainView
 }
 
+// lessonFitsTuning reports whether the shown tuning is the standard tuning
+// lessons are written and parsed in. Other tunings show the overlay, chord
+// finder and quiz, but not the lesson.
+func (m Model) lessonFitsTuning() bool {
+	return slices.Equal(m.tuning, theory.StandardTuning)
+}
+
+// overlayPositions generates the CAGED or 3NPS positions of the overlay scale
+// in the shown tuning
+func (m Model) overlayPositions() []theory.Position {
+	return m.scalePositions(m.tuning)
+}
+
+// lessonPositions are the overlay scale's positions in standard tuning, the
+// tuning generated lessons are written in
+func (m Model) lessonPositions() []theory.Position {
+	return m.scalePositions(theory.StandardTuning)
+}
+
+// scalePositions generates the CAGED or 3NPS positions of the overlay scale
+// in a tuning. In standard tuning the templates of the definitions file
+// follow; they are written and checked for it.
+func (m Model) scalePositions(tuning []theory.Note) []theory.Position {
+	name := theory.ScaleOrder[m.overlayScale]
+	formula := theory.Scales[name]
+	positions, posType := theory.GenerateCAGED(formula, tuning), theory.PositionTypeCAGED
+	if m.positionThree {
+		positions, posType = theory.GenerateThreeNPS(formula, tuning), theory.PositionType3NPS
+	}
+	if !slices.Equal(tuning, theory.StandardTuning) {
+		return positions
+	}
+	// Templates from the definitions file follow, numbered on
+	for _, pos := range theory.UserPositions(name, posType) {
+		pos.Index = len(positions) + 1
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// positionSystem names the position system being browsed
+func (m Model) positionSystem() string {
+	if m.positionThree {
+		return "3NPS"
+	}
+	return "CAGED"
+}
+
+// defaultOverlayScale picks the overlay scale that matches the lesson key:
+// minor keys start on the minor pentatonic, major keys on the major scale
+func defaultOverlayScale(l lesson.Lesson) int {
+	name := "major"
+	if l.Key().Minor {
+		name = "minor_pentatonic"
+	}
+	for i, s := range theory.ScaleOrder {
+		if s == name {
+			return i
+		}
+	}
+	return 0
+}
+
 func tick(bpm int) tea.Cmd {
 	return tea.Tick(time.Duration(60000/bpm)*time.Millisecond, func(t time.Time) tea.Msg {
 		return TickMsg(t)
@@ -537,15 +1144,32 @@ func listenMetronomeBeat(player *audio.MetronomePlayer) tea.Cmd {
 }
 
 func parseNote(n string) theory.Note {
-	n = strings.TrimSpace(n)
-	for i, name := range theory.NoteNames {
-		if strings.EqualFold(name, n) {
-			return theory.Note(i)
-		}
+	if note, ok := theory.ParseNote(n); ok {
+		return note
 	}
 	return theory.C
 }
 
+// wrapHelp joins help entries with two spaces, wrapping lines at width
+func wrapHelp(entries []string, width int) string {
+	var lines []string
+	line := ""
+	for _, e := range entries {
+		if line != "" && lipgloss.Width(line)+2+lipgloss.Width(e) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += "  "
+		}
+		line += e
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func status(b bool) string {
 	if b {
 		return "ON"
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"testing"
+
+	"guitui/internal/lesson"
+	"guitui/internal/theory"
+)
+
+func TestLessonFitsTuning(t *testing.T) {
+	tests := []struct {
+		name   string
+		tuning []theory.Note
+		fits   bool
+	}{
+		{"standard", theory.StandardTuning, true},
+		{"standard copy", []theory.Note{theory.E, theory.A, theory.D, theory.G, theory.B, theory.E}, true},
+		{"drop D", []theory.Note{theory.D, theory.A, theory.D, theory.G, theory.B, theory.E}, false},
+		{"DADGAD", []theory.Note{theory.D, theory.A, theory.D, theory.G, theory.A, theory.D}, false},
+	}
+	for _, tt := range tests {
+		if got := (Model{tuning: tt.tuning}).lessonFitsTuning(); got != tt.fits {
+			t.Errorf("%s: fits = %v, want %v", tt.name, got, tt.fits)
+		}
+	}
+}
+
+func TestDefaultOverlayScale(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"Am", "minor_pentatonic"},
+		{"G", "major"},
+		{"", "major"},
+	}
+	for _, tt := range tests {
+		l := lesson.Lesson{KeyStr: tt.key}
+		if got := theory.ScaleOrder[defaultOverlayScale(l)]; got != tt.want {
+			t.Errorf("key %q: overlay %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,445 @@
+package ui
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"guitui/internal/theory"
+	"guitui/internal/ui/components"
+)
+
+// quizMode is what the note quiz asks
+type quizMode int
+
+const (
+	quizNameNote quizMode = iota // A fret is marked: name its note
+	quizFindNote                 // A note is named: find every fret that plays it
+)
+
+// quizRegion is a stretch of the neck the quiz keeps statistics for; the
+// questions can be limited to one
+type quizRegion struct {
+	Name      string
+	Low, High int
+}
+
+// quizRegions split the neck by the inlays
+var quizRegions = []quizRegion{
+	{"0-4", 0, 4},
+	{"5-8", 5, 8},
+	{"9-12", 9, 12},
+	{"13-17", 13, 17},
+	{"18-24", 18, 24},
+}
+
+// quizTarget is the answer time of a known note; slower answers count as a
+// weak spot
+const quizTarget = 3 * time.Second
+
+// quizHelp lists the keys of the quiz
+const quizHelp = "a-g name (Shift = ♯, - first = ♭) • ←→↑↓ move • space mark • s skip • tab mode • r/R frets • Q/esc close"
+
+// quizCell is a fret on a string (0 = low E)
+type quizCell struct {
+	s, f int
+}
+
+// quizSpot is the statistics bucket of a cell: its string and fret region
+type quizSpot struct {
+	s, region int
+}
+
+// quizStat is the record of one spot
+type quizStat struct {
+	attempts int
+	correct  int
+	time     time.Duration // Sum over the correct answers
+}
+
+// average is the mean time of the correct answers
+func (s quizStat) average() time.Duration {
+	if s.correct == 0 {
+		return 0
+	}
+	return s.time / time.Duration(s.correct)
+}
+
+// weight is how often the spot is asked: missed and slow spots come up
+// more, unseen ones often enough to be measured
+func (s quizStat) weight() float64 {
+	if s.attempts == 0 {
+		return 2
+	}
+	misses := float64(s.attempts-s.correct) / float64(s.attempts)
+	slow := 0.0
+	if s.correct > 0 {
+		slow = min(float64(s.average())/float64(quizTarget), 3)
+	}
+	return 0.5 + 4*misses + slow
+}
+
+// quiz is the fretboard note quiz: name the note of a marked fret, or find
+// every fret of a named note with a cursor. Answers are timed and recorded
+// by string and fret region, and weak spots are asked more often.
+type quiz struct {
+	active bool
+	mode   quizMode
+	region int        // Index into quizRegions, -1 = every fret
+	key    theory.Key // Spells the notes: the lesson's key when the quiz opened
+	flat   bool       // Name mode: "-" was pressed, the next letter is flat
+	rng    *rand.Rand
+	stats  map[quizSpot]quizStat
+
+	cell   quizCell          // Name mode: the asked fret
+	note   theory.Note       // Find mode: the asked note
+	found  map[quizCell]bool // Find mode: frets found so far
+	cursor quizCell
+	asked  time.Time // When the question (or the last find) was shown
+
+	feedback        string // Result of the last answer
+	correct         bool
+	answered, right int // Totals of the session
+}
+
+// open starts the quiz with a new question, spelling notes in key;
+// statistics are kept for the session
+func (q *quiz) open(key theory.Key, tuning []theory.Note, fretCount int, now time.Time) {
+	q.active = true
+	q.key = key
+	if q.rng == nil {
+		q.rng = rand.New(rand.NewSource(now.UnixNano()))
+		q.stats = make(map[quizSpot]quizStat)
+		q.region = -1
+	}
+	q.feedback = ""
+	q.next(tuning, fretCount, now)
+}
+
+// fit follows a change of the fret count: a region past the neck becomes
+// every fret, and a question or cursor off the neck moves onto it
+func (q *quiz) fit(tuning []theory.Note, fretCount int, now time.Time) {
+	if q.region >= 0 && quizRegions[q.region].Low > fretCount {
+		q.region = -1
+	}
+	low, high := q.frets(fretCount)
+	q.cursor.f = max(low, min(high, q.cursor.f))
+	switch q.mode {
+	case quizNameNote:
+		if q.cell.f < low || q.cell.f > high {
+			q.next(tuning, fretCount, now)
+		}
+	case quizFindNote:
+		// Frets found off the neck no longer count toward the question
+		for c := range q.found {
+			if c.f < low || c.f > high {
+				delete(q.found, c)
+			}
+		}
+	}
+}
+
+// frets is the fret range questions are asked in
+func (q quiz) frets(fretCount int) (int, int) {
+	if q.region < 0 || quizRegions[q.region].Low > fretCount {
+		return 0, fretCount
+	}
+	r := quizRegions[q.region]
+	return r.Low, min(r.High, fretCount)
+}
+
+// spot is the statistics bucket of a cell
+func spot(c quizCell) quizSpot {
+	for i, r := range quizRegions {
+		if c.f <= r.High {
+			return quizSpot{c.s, i}
+		}
+	}
+	return quizSpot{c.s, len(quizRegions) - 1}
+}
+
+// cells lists the frets in range, low string first
+func (q quiz) cells(tuning []theory.Note, fretCount int) []quizCell {
+	low, high := q.frets(fretCount)
+	var cells []quizCell
+	for s := range tuning {
+		for f := low; f <= high; f++ {
+			cells = append(cells, quizCell{s, f})
+		}
+	}
+	return cells
+}
+
+// locations lists the frets in range that play the asked note
+func (q quiz) locations(tuning []theory.Note, fretCount int) []quizCell {
+	var cells []quizCell
+	for _, c := range q.cells(tuning, fretCount) {
+		if theory.CalculateNote(tuning[c.s], c.f) == q.note {
+			cells = append(cells, c)
+		}
+	}
+	return cells
+}
+
+// next asks a new question, weighted toward weak spots and never the same
+// fret or note twice in a row
+func (q *quiz) next(tuning []theory.Note, fretCount int, now time.Time) {
+	q.asked = now
+	q.found = make(map[quizCell]bool)
+	cells := q.cells(tuning, fretCount)
+
+	switch q.mode {
+	case quizNameNote:
+		weights := make([]float64, len(cells))
+		for i, c := range cells {
+			if c != q.cell {
+				weights[i] = q.stats[spot(c)].weight()
+			}
+		}
+		q.cell = cells[q.pick(weights)]
+
+	case quizFindNote:
+		// A note is as weak as the average of its frets
+		weights := make([]float64, 12)
+		counts := make([]int, 12)
+		for _, c := range cells {
+			n := theory.CalculateNote(tuning[c.s], c.f)
+			weights[n] += q.stats[spot(c)].weight()
+			counts[n]++
+		}
+		for n := range weights {
+			if counts[n] > 0 && theory.Note(n) != q.note {
+				weights[n] /= float64(counts[n])
+			} else {
+				weights[n] = 0
+			}
+		}
+		q.note = theory.Note(q.pick(weights))
+		low, high := q.frets(fretCount)
+		q.cursor.f = max(low, min(high, q.cursor.f))
+	}
+}
+
+// pick draws an index with probability proportional to its weight
+func (q *quiz) pick(weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	r := q.rng.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	for i := len(weights) - 1; i > 0; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// record counts an answer for a fret
+func (q *quiz) record(c quizCell, correct bool, elapsed time.Duration) {
+	st := q.stats[spot(c)]
+	st.attempts++
+	q.answered++
+	if correct {
+		st.correct++
+		st.time += elapsed
+		q.right++
+	}
+	q.stats[spot(c)] = st
+}
+
+// quizPassKeys keep their normal meaning during the quiz: quit, help, the
+// tuning and the fret count, which the questions follow
+var quizPassKeys = map[string]bool{"ctrl+c": true, "?": true, "x": true, "X": true, "f": true}
+
+// handleKey answers or moves for a key press. Every key but quizPassKeys
+// belongs to the quiz, so a stray key can't start the metronome or change
+// the lesson mid-question; it reports false for the pass keys.
+func (q *quiz) handleKey(key string, tuning []theory.Note, fretCount int, now time.Time) bool {
+	if quizPassKeys[key] {
+		return false
+	}
+	switch key {
+	case "Q", "esc":
+		q.active = false
+		return true
+	case "tab":
+		q.mode = 1 - q.mode
+		q.feedback = ""
+		q.next(tuning, fretCount, now)
+		return true
+	case "r", "R":
+		// every fret -> each region that fits on the neck -> every fret
+		step := 1
+		if key == "R" {
+			step = len(quizRegions)
+		}
+		for {
+			q.region = (q.region+1+step)%(len(quizRegions)+1) - 1
+			if q.region < 0 || quizRegions[q.region].Low <= fretCount {
+				break
+			}
+		}
+		q.next(tuning, fretCount, now)
+		return true
+	case "s":
+		q.skip(tuning, fretCount)
+		q.next(tuning, fretCount, now)
+		return true
+	}
+
+	if q.mode == quizNameNote {
+		if key == "-" {
+			q.flat = !q.flat
+			return true
+		}
+		if sn, ok := quizNoteKeys[key]; ok {
+			if q.flat {
+				sn.Accidental = -1
+			}
+			q.flat = false
+			q.answer(sn, tuning, fretCount, now)
+		}
+		return true
+	}
+
+	low, high := q.frets(fretCount)
+	switch key {
+	case "right", "l":
+		q.cursor.f = min(q.cursor.f+1, high)
+	case "left", "h":
+		q.cursor.f = max(q.cursor.f-1, low)
+	case "up", "k": // Up the screen: toward the high e
+		q.cursor.s = min(q.cursor.s+1, len(tuning)-1)
+	case "down", "j":
+		q.cursor.s = max(q.cursor.s-1, 0)
+	case " ", "enter":
+		q.mark(tuning, fretCount, now)
+	}
+	return true
+}
+
+// quizNoteKeys are the answers of name mode: a letter, Shift for sharp.
+// "-" before a letter makes it flat. The fret counts as any spelling of
+// its note: E# is F.
+var quizNoteKeys = map[string]theory.SpelledNote{
+	"c": {Letter: 0}, "d": {Letter: 1}, "e": {Letter: 2}, "f": {Letter: 3}, "g": {Letter: 4}, "a": {Letter: 5}, "b": {Letter: 6},
+	"C": {Letter: 0, Accidental: 1}, "D": {Letter: 1, Accidental: 1}, "E": {Letter: 2, Accidental: 1}, "F": {Letter: 3, Accidental: 1},
+	"G": {Letter: 4, Accidental: 1}, "A": {Letter: 5, Accidental: 1}, "B": {Letter: 6, Accidental: 1},
+}
+
+// answer checks a note named for the marked fret and asks the next question
+func (q *quiz) answer(sn theory.SpelledNote, tuning []theory.Note, fretCount int, now time.Time) {
+	want := theory.CalculateNote(tuning[q.cell.s], q.cell.f)
+	elapsed := now.Sub(q.asked)
+	q.correct = sn.Note() == want
+	q.record(q.cell, q.correct, elapsed)
+	if q.correct {
+		q.feedback = fmt.Sprintf("✓ %s (%.1fs)", q.key.Spell(want), elapsed.Seconds())
+	} else {
+		q.feedback = fmt.Sprintf("✗ %s, not %s", q.key.Spell(want), sn)
+	}
+	q.next(tuning, fretCount, now)
+}
+
+// mark checks the fret under the cursor in find mode. Each find is timed
+// from the previous one; a wrong fret is a miss for that fret, as its note
+// is not known. The next question comes once every fret is found.
+func (q *quiz) mark(tuning []theory.Note, fretCount int, now time.Time) {
+	c := q.cursor
+	if q.found[c] {
+		return
+	}
+	if n := theory.CalculateNote(tuning[c.s], c.f); n != q.note {
+		q.record(c, false, 0)
+		q.correct = false
+		q.feedback = fmt.Sprintf("✗ string %d fret %d is %s", len(tuning)-c.s, c.f, q.key.Spell(n))
+		return
+	}
+	q.record(c, true, now.Sub(q.asked))
+	q.found[c] = true
+	q.asked = now
+
+	if total := len(q.locations(tuning, fretCount)); len(q.found) >= total {
+		q.correct = true
+		q.feedback = fmt.Sprintf("✓ all %d %s", total, q.key.Spell(q.note))
+		q.next(tuning, fretCount, now)
+	}
+}
+
+// skip gives up the question: the frets not named or found are misses
+func (q *quiz) skip(tuning []theory.Note, fretCount int) {
+	q.correct = false
+	if q.mode == quizNameNote {
+		q.record(q.cell, false, 0)
+		q.feedback = fmt.Sprintf("✗ skipped: %s", q.key.Spell(theory.CalculateNote(tuning[q.cell.s], q.cell.f)))
+		return
+	}
+	for _, c := range q.locations(tuning, fretCount) {
+		if !q.found[c] {
+			q.record(c, false, 0)
+		}
+	}
+	q.feedback = fmt.Sprintf("✗ skipped %s", q.key.Spell(q.note))
+}
+
+// info is the info bar text: "QUIZ: Name the note • frets 0-4 • 12/15 (80%) • ✓ G (1.8s)"
+func (q quiz) info(tuning []theory.Note, fretCount int) string {
+	question := "Name the note"
+	if q.flat {
+		question += " (♭)"
+	}
+	if q.mode == quizFindNote {
+		question = fmt.Sprintf("Find every %s (%d/%d)", q.key.Spell(q.note),
+			len(q.found), len(q.locations(tuning, fretCount)))
+	}
+	low, high := q.frets(fretCount)
+	info := fmt.Sprintf("QUIZ: %s • frets %d-%d", question, low, high)
+	if q.answered > 0 {
+		info += fmt.Sprintf(" • %d/%d (%d%%)", q.right, q.answered, q.right*100/q.answered)
+	}
+	if q.feedback != "" {
+		info += " • " + q.feedback
+	}
+	return info
+}
+
+// props fills the fretboard's quiz fields
+func (q quiz) props(props *components.FretboardProps) {
+	props.Key = q.key
+	props.QuizFound = make(map[string]theory.Note)
+	if q.mode == quizNameNote {
+		props.QuizAsk = fmt.Sprintf("%d_%d", q.cell.s, q.cell.f)
+		return
+	}
+	props.QuizCursor = fmt.Sprintf("%d_%d", q.cursor.s, q.cursor.f)
+	for c := range q.found {
+		props.QuizFound[fmt.Sprintf("%d_%d", c.s, c.f)] = q.note
+	}
+}
+
+// statsProps is the record of every string and region for the stats table
+func (q quiz) statsProps(strings int) components.QuizStatsProps {
+	props := components.QuizStatsProps{Target: quizTarget}
+	for _, r := range quizRegions {
+		props.Regions = append(props.Regions, r.Name)
+	}
+	props.Stats = make([][]components.QuizStat, strings)
+	for s := range props.Stats {
+		for region := range quizRegions {
+			st := q.stats[quizSpot{s, region}]
+			props.Stats[s] = append(props.Stats[s], components.QuizStat{
+				Attempts: st.attempts,
+				Correct:  st.correct,
+				Average:  st.average(),
+			})
+		}
+	}
+	return props
+}
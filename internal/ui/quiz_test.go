@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"guitui/internal/theory"
+)
+
+// newTestQuiz is a name-mode quiz in key, asking string 3 (D) fret 1: D#/Eb
+func newTestQuiz(key string) quiz {
+	k, _ := theory.ParseKey(key)
+	return quiz{
+		active: true,
+		region: -1,
+		key:    k,
+		rng:    rand.New(rand.NewSource(1)),
+		stats:  make(map[quizSpot]quizStat),
+		cell:   quizCell{2, 1},
+	}
+}
+
+func TestQuizAnswers(t *testing.T) {
+	tests := []struct {
+		key      string
+		keys     []string
+		correct  bool
+		feedback string
+	}{
+		{"Bb", []string{"-", "e"}, true, "✓ Eb"},
+		{"E", []string{"D"}, true, "✓ D#"},
+		{"Bb", []string{"D"}, true, "✓ Eb"}, // Any spelling counts
+		{"E", []string{"-", "d"}, false, "✗ D#, not Db"},
+		{"C", []string{"e"}, false, "✗ D#, not E"},
+		{"C", []string{"-", "-", "D"}, true, "✓ D#"}, // "-" twice cancels the flat
+	}
+	now := time.Now()
+	for _, tt := range tests {
+		q := newTestQuiz(tt.key)
+		for _, key := range tt.keys {
+			if !q.handleKey(key, theory.StandardTuning, 12, now) {
+				t.Fatalf("%v: %q passed through", tt.keys, key)
+			}
+		}
+		if q.correct != tt.correct || !strings.HasPrefix(q.feedback, tt.feedback) {
+			t.Errorf("%s %v: correct %v %q, want %v %q", tt.key, tt.keys, q.correct, q.feedback, tt.correct, tt.feedback)
+		}
+		if q.answered != 1 || q.flat {
+			t.Errorf("%s %v: %d answers, flat pending %v", tt.key, tt.keys, q.answered, q.flat)
+		}
+	}
+}
+
+func TestQuizSpellsFindMode(t *testing.T) {
+	q := newTestQuiz("F")
+	q.mode = quizFindNote
+	q.note = theory.As
+	q.found = make(map[quizCell]bool)
+	if info := q.info(theory.StandardTuning, 12); !strings.Contains(info, "Find every Bb") {
+		t.Errorf("info %q does not ask for Bb", info)
+	}
+	q.cursor = quizCell{0, 1} // F
+	q.handleKey(" ", theory.StandardTuning, 12, time.Now())
+	if q.feedback != "✗ string 6 fret 1 is F" {
+		t.Errorf("feedback %q", q.feedback)
+	}
+}
+
+func TestQuizPassKeys(t *testing.T) {
+	for _, key := range []string{"ctrl+c", "?", "x", "X", "f"} {
+		q := newTestQuiz("C")
+		if q.handleKey(key, theory.StandardTuning, 12, time.Now()) {
+			t.Errorf("%q was kept by the quiz", key)
+		}
+	}
+}
+
+func TestQuizFitsShorterNeck(t *testing.T) {
+	now := time.Now()
+	q := newTestQuiz("C")
+	q.region = 3 // 13-17
+	q.cell = quizCell{0, 15}
+	q.fit(theory.StandardTuning, 12, now)
+	if q.region != -1 || q.cell.f > 12 {
+		t.Errorf("region %d, cell fret %d on a 12-fret neck", q.region, q.cell.f)
+	}
+
+	q.mode = quizFindNote
+	q.note = theory.E
+	q.found = map[quizCell]bool{{0, 12}: true, {0, 24}: true}
+	q.cursor = quizCell{0, 24}
+	q.fit(theory.StandardTuning, 12, now)
+	if len(q.found) != 1 || q.cursor.f != 12 {
+		t.Errorf("found %v, cursor fret %d", q.found, q.cursor.f)
+	}
+}